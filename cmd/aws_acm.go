@@ -3,7 +3,11 @@ package cmd
 import (
 	"fmt"
 	"lookr/deps" // Importação de pacotes locais ou dependências
+	"math"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -19,62 +23,201 @@ var AcmCmd = &cobra.Command{
 	Run:   queryACM, // Função a ser executada quando o comando `acm` é chamado
 }
 
+// acmExpiringCmd define o subcomando `acm expiring` que falha quando há certificados perto de expirar
+var acmExpiringCmd = &cobra.Command{
+	Use:   "expiring",
+	Short: "List certificates expiring soon and exit non-zero if any are found", // Descrição breve do comando
+	Run:   queryExpiringACM, // Função a ser executada quando o comando `acm expiring` é chamado
+}
+
 // init é chamado antes da execução do programa principal
 func init() {
+	AcmCmd.Flags().BoolP("wide", "w", false, "List the ARNs of the resources using each certificate")
+	acmExpiringCmd.Flags().String("within", "30d", "Report certificates expiring within this period (e.g. 30d, 720h)")
+	AcmCmd.AddCommand(acmExpiringCmd)
 	rootCmd.AddCommand(AcmCmd) // Adiciona o comando `acm` como um subcomando do comando raiz
 }
 
-// queryACM é a função que executa a lógica para consultar certificados ACM da AWS
-func queryACM(cmd *cobra.Command, args []string) {
-	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
-	table.SetHeader([]string{"Certificate ARN", "Region", "Domain Name", "Status", "Type", "Validation Method"}) // Define cabeçalhos da tabela
+// acmCertificate é um certificado ACM com a região onde foi encontrado
+type acmCertificate struct {
+	region string
+	detail *acm.CertificateDetail
+}
+
+// listCertificates descreve todos os certificados ACM das regiões autorizadas, de todos os tipos de chave
+func listCertificates() ([]acmCertificate, error) {
+	certificates := []acmCertificate{}
 
 	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
 	for _, region := range AuthRegions { // Itera sobre cada região autorizada
 		sess, err := session.NewSession(&aws.Config{
 			Region: aws.String(region), // Configura a sessão com a região atual
 		})
-
 		if err != nil {
-			fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
-			return
+			return nil, err
 		}
 
 		acmClient := acm.New(sess) // Cria um novo cliente ACM com a sessão configurada
 
-		input := &acm.ListCertificatesInput{} // Cria um input para listar certificados ACM
-
-		result, err := acmClient.ListCertificates(input) // Lista os certificados ACM na região atual
-		if err != nil {
-			fmt.Println("failed to list AWS ACM certificates,", err) // Imprime erro se a listagem falhar
-			return
+		arns := []*string{}
+		input := &acm.ListCertificatesInput{ // Sem filtro, ListCertificates retorna apenas chaves RSA_2048
+			Includes: &acm.Filters{KeyTypes: aws.StringSlice(acm.KeyAlgorithm_Values())},
 		}
-
-		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
-
-		for _, certificate := range result.CertificateSummaryList { // Itera sobre cada certificado listado
-			describeInput := &acm.DescribeCertificateInput{
-				CertificateArn: certificate.CertificateArn, // Configura input para descrever o certificado
+		err = acmClient.ListCertificatesPages(input, func(page *acm.ListCertificatesOutput, lastPage bool) bool {
+			for _, certificate := range page.CertificateSummaryList {
+				arns = append(arns, certificate.CertificateArn)
 			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
 
-			certificateDetails, err := acmClient.DescribeCertificate(describeInput) // Descreve o certificado ACM
+		for _, arn := range arns {
+			certificateDetails, err := acmClient.DescribeCertificate(&acm.DescribeCertificateInput{CertificateArn: arn}) // Descreve o certificado ACM
 			if err != nil {
-				fmt.Println("failed to describe ACM certificate,", err) // Imprime erro se a descrição falhar
-				return
+				return nil, err
 			}
+			certificates = append(certificates, acmCertificate{region: region, detail: certificateDetails.Certificate})
+		}
+	}
+	return certificates, nil
+}
 
-			// Cria uma linha com os detalhes do certificado para adicionar à tabela
-			row := []string{
-				*certificate.CertificateArn,
-				regionName,
-				*certificate.DomainName,
-				*certificateDetails.Certificate.Status,
-				*certificateDetails.Certificate.Type,
-				*certificateDetails.Certificate.DomainValidationOptions[0].ValidationMethod,
-				*certificate.CertificateArn,
-			}
-			table.Append(row) // Adiciona a linha à tabela
+// queryACM é a função que executa a lógica para consultar certificados ACM da AWS
+func queryACM(cmd *cobra.Command, args []string) {
+	wide, _ := cmd.Flags().GetBool("wide")
+
+	certificates, err := listCertificates()
+	if err != nil {
+		fmt.Println("failed to list AWS ACM certificates,", err) // Imprime erro se a listagem falhar
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Certificate ARN", "Region", "Domain Name", "Status", "Type", "Validation Method", "Key Algorithm", "Not After", "Days Left", "Renewal", "In Use By"}) // Define cabeçalhos da tabela
+	table.SetAutoWrapText(false)
+
+	for _, certificate := range certificates {
+		detail := certificate.detail
+
+		validationMethod := ""
+		if len(detail.DomainValidationOptions) > 0 { // Certificados importados não têm validação
+			validationMethod = aws.StringValue(detail.DomainValidationOptions[0].ValidationMethod)
+		}
+
+		inUseBy := fmt.Sprintf("%d resources", len(detail.InUseBy))
+		if wide {
+			inUseBy = strings.Join(aws.StringValueSlice(detail.InUseBy), "\n")
+		}
+
+		// Cria uma linha com os detalhes do certificado para adicionar à tabela
+		row := []string{
+			aws.StringValue(detail.CertificateArn),
+			deps.GetRegionName(certificate.region),
+			aws.StringValue(detail.DomainName),
+			aws.StringValue(detail.Status),
+			aws.StringValue(detail.Type),
+			validationMethod,
+			aws.StringValue(detail.KeyAlgorithm),
+			notAfter(detail),
+			daysLeft(detail),
+			renewalStatus(detail),
+			inUseBy,
 		}
+		table.Append(row) // Adiciona a linha à tabela
 	}
 	table.Render() // Renderiza a tabela com os resultados
 }
+
+// queryExpiringACM lista os certificados que expiram dentro do período ou já expiraram e encerra
+// com código 1 se houver algum, para uso em cron e monitoramento
+func queryExpiringACM(cmd *cobra.Command, args []string) {
+	withinFlag, _ := cmd.Flags().GetString("within")
+
+	within, err := parseDays(withinFlag)
+	if err != nil {
+		fmt.Println("invalid --within,", err)
+		os.Exit(2)
+	}
+
+	certificates, err := listCertificates()
+	if err != nil {
+		fmt.Println("failed to list AWS ACM certificates,", err) // Imprime erro se a listagem falhar
+		os.Exit(2)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Certificate ARN", "Region", "Domain Name", "Status", "Not After", "Days Left", "Renewal", "In Use"}) // Define cabeçalhos da tabela
+	table.SetAutoWrapText(false)
+
+	expiring := 0
+	for _, certificate := range certificates {
+		detail := certificate.detail
+		if detail.NotAfter == nil || time.Until(*detail.NotAfter) > within { // Inclui os já expirados (dias negativos)
+			continue
+		}
+
+		expiring++
+		table.Append([]string{
+			aws.StringValue(detail.CertificateArn),
+			deps.GetRegionName(certificate.region),
+			aws.StringValue(detail.DomainName),
+			aws.StringValue(detail.Status),
+			notAfter(detail),
+			daysLeft(detail),
+			renewalStatus(detail),
+			yesNo(len(detail.InUseBy) > 0),
+		})
+	}
+
+	if expiring == 0 {
+		fmt.Printf("No certificates expiring within %s\n", withinFlag)
+		return
+	}
+	table.Render() // Renderiza a tabela com os resultados
+	os.Exit(1)     // Sinaliza ao cron que há certificados perto de expirar
+}
+
+// notAfter formata a data de expiração do certificado
+func notAfter(detail *acm.CertificateDetail) string {
+	if detail.NotAfter == nil {
+		return ""
+	}
+	return detail.NotAfter.Format("2006-01-02")
+}
+
+// daysLeft retorna quantos dias faltam para o certificado expirar, negativo se já expirou
+func daysLeft(detail *acm.CertificateDetail) string {
+	if detail.NotAfter == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d", int(math.Floor(time.Until(*detail.NotAfter).Hours()/24)))
+}
+
+// renewalStatus combina a elegibilidade para renovação gerenciada com o status da última renovação
+func renewalStatus(detail *acm.CertificateDetail) string {
+	status := strings.ToLower(aws.StringValue(detail.RenewalEligibility))
+	if summary := detail.RenewalSummary; summary != nil {
+		status += ", " + strings.ToLower(aws.StringValue(summary.RenewalStatus))
+		if reason := aws.StringValue(summary.RenewalStatusReason); reason != "" {
+			status += " (" + reason + ")"
+		}
+	}
+	return status
+}
+
+// parseDays converte períodos como "30d" ou "720h" em uma duração positiva; números sem unidade são dias
+func parseDays(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if days, atoiErr := strconv.Atoi(strings.TrimSuffix(value, "d")); atoiErr == nil {
+		duration, err = time.Duration(days)*24*time.Hour, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("period must be positive, got %s", value)
+	}
+	return duration, nil
+}
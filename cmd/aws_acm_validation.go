@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/acm"     // Pacote para AWS Certificate Manager (ACM)
+	"github.com/aws/aws-sdk-go/service/route53" // Pacote para AWS Route 53
+	"github.com/olekukonko/tablewriter"         // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                    // Pacote para criação de CLI usando Cobra
+)
+
+// acmValidationCmd define o subcomando `acm validation` que diagnostica validações DNS pendentes
+var acmValidationCmd = &cobra.Command{
+	Use:   "validation <certificate-arn>",
+	Short: "Check the DNS validation records of a certificate and create the missing ones in Route 53", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   checkACMValidation, // Função a ser executada quando o comando `acm validation` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	acmValidationCmd.Flags().BoolP("yes", "y", false, "Create missing records without asking for confirmation")
+	AcmCmd.AddCommand(acmValidationCmd) // Adiciona o comando `validation` como um subcomando de `acm`
+}
+
+// checkACMValidation exibe o status de validação de cada domínio e o CNAME esperado, verifica se ele
+// existe em uma zona do Route 53 da conta e oferece criar os registros ausentes
+func checkACMValidation(cmd *cobra.Command, args []string) {
+	skipConfirmation, _ := cmd.Flags().GetBool("yes")
+	certificateArn := args[0]
+
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(regionFromARN(certificateArn)), // O certificado só existe na região do ARN
+	})
+	if err != nil {
+		fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+		return
+	}
+
+	certificateDetails, err := acm.New(sess).DescribeCertificate(&acm.DescribeCertificateInput{CertificateArn: aws.String(certificateArn)})
+	if err != nil {
+		fmt.Println("failed to describe ACM certificate,", err)
+		return
+	}
+
+	route53Client, err := newRoute53Client()
+	if err != nil {
+		fmt.Println("failed to create session,", err)
+		return
+	}
+
+	zones, err := listHostedZones(route53Client)
+	if err != nil {
+		fmt.Println("failed to list Route 53 hosted zones,", err)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Domain", "Validation Status", "Method", "Expected Record", "Hosted Zone", "Record Status"}) // Define cabeçalhos da tabela
+	table.SetAutoWrapText(false)
+
+	missing := map[*route53.HostedZone][]*acm.ResourceRecord{} // Zona -> registros a criar
+	seen := map[string]bool{} // Domínios curinga e apex compartilham o mesmo registro
+	for _, option := range certificateDetails.Certificate.DomainValidationOptions {
+		row := []string{
+			aws.StringValue(option.DomainName),
+			aws.StringValue(option.ValidationStatus),
+			aws.StringValue(option.ValidationMethod),
+		}
+
+		record := option.ResourceRecord
+		if aws.StringValue(option.ValidationMethod) != acm.ValidationMethodDns || record == nil {
+			table.Append(append(row, "", "", "")) // Validação por email ou registro ainda não gerado
+			continue
+		}
+		row = append(row, fmt.Sprintf("%s %s %s", aws.StringValue(record.Name), aws.StringValue(record.Type), aws.StringValue(record.Value)))
+
+		zone := hostedZoneForName(zones, aws.StringValue(record.Name))
+		if zone == nil {
+			table.Append(append(row, "", "no hosted zone in this account"))
+			continue
+		}
+
+		status, err := validationRecordStatus(route53Client, zone, record)
+		if err != nil {
+			fmt.Println("failed to list resource record sets,", err)
+			return
+		}
+		if status == "missing" && !seen[aws.StringValue(record.Name)] {
+			missing[zone] = append(missing[zone], record)
+		}
+		seen[aws.StringValue(record.Name)] = true
+		table.Append(append(row, zoneLabel(zone), status))
+	}
+	table.Render() // Renderiza a tabela com os resultados
+
+	pending := []*route53.HostedZone{} // Zonas com registros a criar, em ordem estável entre execuções
+	for zone := range missing {
+		pending = append(pending, zone)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		if aws.StringValue(pending[i].Name) != aws.StringValue(pending[j].Name) {
+			return aws.StringValue(pending[i].Name) < aws.StringValue(pending[j].Name)
+		}
+		return aws.StringValue(pending[i].Id) < aws.StringValue(pending[j].Id)
+	})
+
+	for _, zone := range pending {
+		records := missing[zone]
+		if !skipConfirmation && !confirm(fmt.Sprintf("Create %d validation records in %s?", len(records), zoneLabel(zone))) {
+			continue
+		}
+
+		changes := []*route53.Change{}
+		for _, record := range records {
+			changes = append(changes, &route53.Change{
+				Action: aws.String(route53.ChangeActionUpsert),
+				ResourceRecordSet: &route53.ResourceRecordSet{
+					Name:            record.Name,
+					Type:            record.Type,
+					TTL:             aws.Int64(300),
+					ResourceRecords: []*route53.ResourceRecord{{Value: record.Value}},
+				},
+			})
+		}
+
+		result, err := route53Client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+			HostedZoneId: zone.Id,
+			ChangeBatch: &route53.ChangeBatch{
+				Comment: aws.String("ACM validation for " + certificateArn),
+				Changes: changes,
+			},
+		})
+		if err != nil {
+			fmt.Println("failed to change resource record sets,", err)
+			return
+		}
+		fmt.Printf("Created %d records in %s: %s (%s)\n", len(changes), zoneLabel(zone), aws.StringValue(result.ChangeInfo.Id), aws.StringValue(result.ChangeInfo.Status))
+	}
+}
+
+// hostedZoneForName retorna a zona pública com o maior sufixo em comum com o nome
+func hostedZoneForName(zones []*route53.HostedZone, name string) *route53.HostedZone {
+	name = normalizeDNSName(name)
+
+	var best *route53.HostedZone
+	for _, zone := range zones {
+		if zone.Config != nil && aws.BoolValue(zone.Config.PrivateZone) { // A ACM valida pelo DNS público
+			continue
+		}
+		zoneName := normalizeDNSName(aws.StringValue(zone.Name))
+		if name != zoneName && !strings.HasSuffix(name, "."+zoneName) {
+			continue
+		}
+		if best == nil || len(zoneName) > len(normalizeDNSName(aws.StringValue(best.Name))) {
+			best = zone
+		}
+	}
+	return best
+}
+
+// validationRecordStatus verifica se o registro de validação existe na zona com o valor esperado
+func validationRecordStatus(route53Client *route53.Route53, zone *route53.HostedZone, record *acm.ResourceRecord) (string, error) {
+	result, err := route53Client.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    zone.Id,
+		StartRecordName: record.Name,
+		StartRecordType: record.Type,
+		MaxItems:        aws.String("1"),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, recordSet := range result.ResourceRecordSets {
+		if normalizeDNSName(aws.StringValue(recordSet.Name)) != normalizeDNSName(aws.StringValue(record.Name)) ||
+			aws.StringValue(recordSet.Type) != aws.StringValue(record.Type) {
+			continue
+		}
+		for _, value := range recordSet.ResourceRecords {
+			if normalizeDNSName(aws.StringValue(value.Value)) == normalizeDNSName(aws.StringValue(record.Value)) {
+				return "present", nil
+			}
+		}
+		return "wrong value", nil
+	}
+	return "missing", nil
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"lookr/deps" // Importação de pacotes locais ou dependências
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2" // Pacote para Amazon EC2
+	"github.com/olekukonko/tablewriter"     // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                // Pacote para criação de CLI usando Cobra
+)
+
+// AmisCmd define o comando `amis` para o CLI
+var AmisCmd = &cobra.Command{
+	Use:   "amis",
+	Short: "Query owned AMIs in different regions and flag unused images", // Descrição breve do comando
+	Run:   queryAMIs, // Função a ser executada quando o comando `amis` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	AmisCmd.Flags().Bool("unused", false, "Show only AMIs not used by any instance or launch template")
+	rootCmd.AddCommand(AmisCmd) // Adiciona o comando `amis` como um subcomando do comando raiz
+}
+
+// queryAMIs é a função que executa a lógica para consultar as AMIs da conta
+func queryAMIs(cmd *cobra.Command, args []string) {
+	onlyUnused, _ := cmd.Flags().GetBool("unused")
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Image ID", "Region", "Name", "Size (GB)", "Age (days)", "State", "Snapshots", "Unused"}) // Define cabeçalhos da tabela
+
+	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
+	for _, region := range AuthRegions { // Itera sobre cada região autorizada
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região atual
+		})
+
+		if err != nil {
+			fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+			return
+		}
+
+		ec2Client := ec2.New(sess) // Cria um novo cliente EC2 com a sessão configurada
+
+		images, err := ownedImages(ec2Client) // Lista as AMIs pertencentes à conta
+		if err != nil {
+			fmt.Println("failed to describe AMIs,", err) // Imprime erro se a descrição falhar
+			return
+		}
+
+		if len(images) == 0 {
+			continue // Evita consultar instâncias e templates em regiões sem AMIs
+		}
+
+		usedImages, err := usedImageIDs(ec2Client) // Obtém as AMIs referenciadas por instâncias e launch templates
+		if err != nil {
+			fmt.Println("failed to collect AMI usage,", err) // Imprime erro se a coleta falhar
+			return
+		}
+
+		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
+
+		for _, image := range images { // Itera sobre cada AMI listada
+			unused := !usedImages[aws.StringValue(image.ImageId)]
+			if onlyUnused && !unused {
+				continue
+			}
+
+			var size int64
+			snapshots := []string{}
+			for _, mapping := range image.BlockDeviceMappings { // Soma os volumes EBS que compõem a AMI
+				if mapping.Ebs == nil {
+					continue
+				}
+				size += aws.Int64Value(mapping.Ebs.VolumeSize)
+				if mapping.Ebs.SnapshotId != nil {
+					snapshots = append(snapshots, *mapping.Ebs.SnapshotId)
+				}
+			}
+
+			// Cria uma linha com os detalhes da AMI para adicionar à tabela
+			row := []string{
+				aws.StringValue(image.ImageId),
+				regionName,
+				aws.StringValue(image.Name),
+				fmt.Sprintf("%d", size),
+				imageAge(image.CreationDate),
+				aws.StringValue(image.State),
+				strings.Join(snapshots, ", "),
+				yesNo(unused),
+			}
+			table.Append(row) // Adiciona a linha à tabela
+		}
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
+
+// ownedImages retorna todas as AMIs pertencentes à conta na região do cliente
+func ownedImages(ec2Client *ec2.EC2) ([]*ec2.Image, error) {
+	input := &ec2.DescribeImagesInput{
+		Owners: []*string{aws.String("self")}, // Restringe às AMIs da própria conta
+	}
+
+	images := []*ec2.Image{}
+	err := ec2Client.DescribeImagesPages(input, func(page *ec2.DescribeImagesOutput, lastPage bool) bool {
+		images = append(images, page.Images...)
+		return true
+	})
+	return images, err
+}
+
+// usedImageIDs retorna o conjunto de AMIs usadas por instâncias ou pelas versões
+// padrão e mais recente dos launch templates da região
+func usedImageIDs(ec2Client *ec2.EC2) (map[string]bool, error) {
+	used := map[string]bool{}
+
+	err := ec2Client.DescribeInstancesPages(&ec2.DescribeInstancesInput{}, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				used[aws.StringValue(instance.ImageId)] = true
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	templates := []*ec2.LaunchTemplate{}
+	err = ec2Client.DescribeLaunchTemplatesPages(&ec2.DescribeLaunchTemplatesInput{}, func(page *ec2.DescribeLaunchTemplatesOutput, lastPage bool) bool {
+		templates = append(templates, page.LaunchTemplates...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, template := range templates { // Consulta as versões relevantes de cada launch template
+		versions, err := ec2Client.DescribeLaunchTemplateVersions(&ec2.DescribeLaunchTemplateVersionsInput{
+			LaunchTemplateId: template.LaunchTemplateId,
+			Versions:         []*string{aws.String("$Default"), aws.String("$Latest")},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, version := range versions.LaunchTemplateVersions {
+			if version.LaunchTemplateData != nil && version.LaunchTemplateData.ImageId != nil {
+				used[*version.LaunchTemplateData.ImageId] = true
+			}
+		}
+	}
+	return used, nil
+}
+
+// imageAge converte a data de criação de uma AMI (ISO 8601) em idade em dias
+func imageAge(creationDate *string) string {
+	created, err := time.Parse(time.RFC3339, aws.StringValue(creationDate))
+	if err != nil {
+		return aws.StringValue(creationDate) // Retorna a data original se a conversão falhar
+	}
+	return ageInDays(created)
+}
+
+// ageInDays retorna quantos dias se passaram desde `t`
+func ageInDays(t time.Time) string {
+	return fmt.Sprintf("%d", int(time.Since(t).Hours()/24))
+}
+
+// yesNo converte um booleano em "Yes" ou "No" para exibição na tabela
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
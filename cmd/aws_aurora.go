@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"lookr/deps" // Importação de pacotes locais ou dependências
 	"os"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch" // Pacote para Amazon CloudWatch
 	"github.com/aws/aws-sdk-go/service/rds" // Pacote para Amazon RDS (Relational Database Service)
 	"github.com/olekukonko/tablewriter" // Pacote para formatação de tabelas
 	"github.com/spf13/cobra" // Pacote para criação de CLI usando Cobra
@@ -19,8 +22,16 @@ var AuroraCmd = &cobra.Command{
 	Run:   queryAurora, // Função a ser executada quando o comando `aurora` é chamado
 }
 
+// auroraTopologyCmd define o subcomando `aurora topology` que exibe cada cluster como uma árvore
+var auroraTopologyCmd = &cobra.Command{
+	Use:   "topology",
+	Short: "Show Aurora clusters as a tree of writer/reader instances and endpoints", // Descrição breve do comando
+	Run:   queryAuroraTopology, // Função a ser executada quando o comando `aurora topology` é chamado
+}
+
 // init é chamado antes da execução do programa principal
 func init() {
+	AuroraCmd.AddCommand(auroraTopologyCmd)
 	rootCmd.AddCommand(AuroraCmd) // Adiciona o comando `aurora` como um subcomando do comando raiz
 }
 
@@ -53,14 +64,9 @@ func queryAurora(cmd *cobra.Command, args []string) {
 		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
 
 		for _, cluster := range result.DBClusters { // Itera sobre cada cluster listado
-			dbInstances := ""
+			dbInstances := []string{}
 			for _, instance := range cluster.DBClusterMembers { // Itera sobre cada instância do cluster
-				dbInstances += *instance.DBInstanceIdentifier + ", "
-			}
-
-			replicaIdentifier := ""
-			if len(cluster.ReadReplicaIdentifiers) > 0 { // Verifica se há réplicas
-				replicaIdentifier = *cluster.ReadReplicaIdentifiers[0] // Define o identificador da primeira réplica
+				dbInstances = append(dbInstances, *instance.DBInstanceIdentifier)
 			}
 
 			// Cria uma linha com os detalhes do cluster para adicionar à tabela
@@ -70,8 +76,8 @@ func queryAurora(cmd *cobra.Command, args []string) {
 				*cluster.Status,
 				*cluster.Engine,
 				*cluster.EngineVersion,
-				dbInstances,
-				replicaIdentifier,
+				strings.Join(dbInstances, ", "),
+				strings.Join(aws.StringValueSlice(cluster.ReadReplicaIdentifiers), ", "),
 				*cluster.DBClusterArn,
 			}
 			table.Append(row) // Adiciona a linha à tabela
@@ -79,3 +85,124 @@ func queryAurora(cmd *cobra.Command, args []string) {
 	}
 	table.Render() // Renderiza a tabela com os resultados
 }
+
+// queryAuroraTopology exibe cada cluster Aurora como uma árvore com instâncias, endpoints,
+// capacidade serverless v2, participação em banco global e configuração de backtrack
+func queryAuroraTopology(cmd *cobra.Command, args []string) {
+	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
+	for _, region := range AuthRegions { // Itera sobre cada região autorizada
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região atual
+		})
+
+		if err != nil {
+			fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+			return
+		}
+
+		rdsClient := rds.New(sess) // Cria um novo cliente RDS com a sessão configurada
+		cwClient := cloudwatch.New(sess) // Cria um novo cliente CloudWatch para o lag de replicação
+
+		clusters := []*rds.DBCluster{}
+		err = rdsClient.DescribeDBClustersPages(&rds.DescribeDBClustersInput{}, func(page *rds.DescribeDBClustersOutput, lastPage bool) bool {
+			clusters = append(clusters, page.DBClusters...)
+			return true
+		})
+		if err != nil {
+			fmt.Println("failed to describe Amazon Aurora clusters,", err) // Imprime erro se a descrição falhar
+			return
+		}
+
+		if len(clusters) == 0 {
+			continue
+		}
+
+		instances := map[string]*rds.DBInstance{} // Instâncias indexadas pelo identificador
+		err = rdsClient.DescribeDBInstancesPages(&rds.DescribeDBInstancesInput{}, func(page *rds.DescribeDBInstancesOutput, lastPage bool) bool {
+			for _, instance := range page.DBInstances {
+				instances[aws.StringValue(instance.DBInstanceIdentifier)] = instance
+			}
+			return true
+		})
+		if err != nil {
+			fmt.Println("failed to describe db instances,", err)
+			return
+		}
+
+		globalMembers := map[string]string{} // Papel no banco global indexado pelo ARN do cluster
+		err = rdsClient.DescribeGlobalClustersPages(&rds.DescribeGlobalClustersInput{}, func(page *rds.DescribeGlobalClustersOutput, lastPage bool) bool {
+			for _, global := range page.GlobalClusters {
+				for _, member := range global.GlobalClusterMembers {
+					role := "secondary"
+					if aws.BoolValue(member.IsWriter) {
+						role = "primary"
+					}
+					globalMembers[aws.StringValue(member.DBClusterArn)] = fmt.Sprintf("%s (%s)", aws.StringValue(global.GlobalClusterIdentifier), role)
+				}
+			}
+			return true
+		})
+		if err != nil {
+			fmt.Println("failed to describe global clusters,", err)
+			return
+		}
+
+		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
+
+		for _, cluster := range clusters { // Itera sobre cada cluster listado
+			root := &treeNode{label: fmt.Sprintf("%s [%s] %s %s - %s", aws.StringValue(cluster.DBClusterIdentifier), regionName,
+				aws.StringValue(cluster.Engine), aws.StringValue(cluster.EngineVersion), aws.StringValue(cluster.Status))}
+
+			for _, member := range cluster.DBClusterMembers { // Writer e readers com classe e AZ
+				role := "Reader"
+				if aws.BoolValue(member.IsClusterWriter) {
+					role = "Writer"
+				}
+				id := aws.StringValue(member.DBInstanceIdentifier)
+				if instance, found := instances[id]; found {
+					root.add("%s: %s (%s, %s)", role, id, aws.StringValue(instance.DBInstanceClass), aws.StringValue(instance.AvailabilityZone))
+				} else {
+					root.add("%s: %s", role, id)
+				}
+			}
+
+			endpoints := root.add("Endpoints")
+			endpoints.add("Cluster: %s", aws.StringValue(cluster.Endpoint))
+			endpoints.add("Reader: %s", aws.StringValue(cluster.ReaderEndpoint))
+			for _, custom := range cluster.CustomEndpoints {
+				endpoints.add("Custom: %s", aws.StringValue(custom))
+			}
+
+			if scaling := cluster.ServerlessV2ScalingConfiguration; scaling != nil {
+				root.add("Serverless v2: %g - %g ACU", aws.Float64Value(scaling.MinCapacity), aws.Float64Value(scaling.MaxCapacity))
+			}
+
+			if membership, found := globalMembers[aws.StringValue(cluster.DBClusterArn)]; found {
+				global := root.add("Global database: %s", membership)
+				if strings.HasSuffix(membership, "(secondary)") { // O lag só é publicado pelos clusters secundários
+					lag, ok, err := latestMetricValue(cwClient, "AWS/RDS", "AuroraGlobalDBReplicationLag", cloudwatch.StatisticAverage,
+						map[string]string{"DBClusterIdentifier": aws.StringValue(cluster.DBClusterIdentifier)}, 15*time.Minute)
+					switch {
+					case err != nil:
+						global.add("Replication lag: %v", err)
+					case ok:
+						global.add("Replication lag: %.0f ms", lag)
+					default:
+						global.add("Replication lag: no data")
+					}
+				}
+			}
+
+			if window := aws.Int64Value(cluster.BacktrackWindow); window > 0 {
+				backtrack := root.add("Backtrack: %s window", time.Duration(window)*time.Second)
+				if cluster.EarliestBacktrackTime != nil {
+					backtrack.add("Earliest: %s", cluster.EarliestBacktrackTime.Format(time.RFC3339))
+				}
+				backtrack.add("Consumed change records: %d", aws.Int64Value(cluster.BacktrackConsumedChangeRecords))
+			}
+
+			printTree(root)
+			fmt.Println()
+		}
+	}
+}
@@ -42,9 +42,7 @@ func queryCloudFront(cmd *cobra.Command, args []string) {
 
 		cfClient := cloudfront.New(sess) // Cria um novo cliente CloudFront com a sessão configurada
 
-		input := &cloudfront.ListDistributionsInput{} // Cria um input para listar distribuições CloudFront
-
-		result, err := cfClient.ListDistributions(input) // Lista as distribuições CloudFront na região atual
+		distributions, err := listDistributions(cfClient) // Lista as distribuições CloudFront na região atual
 		if err != nil {
 			fmt.Println("failed to list Amazon CloudFront distributions,", err) // Imprime erro se a listagem falhar
 			return
@@ -52,7 +50,7 @@ func queryCloudFront(cmd *cobra.Command, args []string) {
 
 		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
 
-		for _, distribution := range result.DistributionList.Items { // Itera sobre cada distribuição listada
+		for _, distribution := range distributions { // Itera sobre cada distribuição listada
 			defaultCacheBehavior := "N/A"
 			if distribution.DefaultCacheBehavior != nil { // Verifica se há comportamento de cache padrão
 				defaultCacheBehavior = *distribution.DefaultCacheBehavior.TargetOriginId // Define o comportamento de cache padrão
@@ -72,3 +70,14 @@ func queryCloudFront(cmd *cobra.Command, args []string) {
 	}
 	table.Render() // Renderiza a tabela com os resultados
 }
+
+// listDistributions retorna todas as distribuições CloudFront da conta
+func listDistributions(cfClient *cloudfront.CloudFront) ([]*cloudfront.DistributionSummary, error) {
+	distributions := []*cloudfront.DistributionSummary{}
+	input := &cloudfront.ListDistributionsInput{} // Cria um input para listar distribuições CloudFront
+	err := cfClient.ListDistributionsPages(input, func(page *cloudfront.ListDistributionsOutput, lastPage bool) bool {
+		distributions = append(distributions, page.DistributionList.Items...)
+		return true
+	})
+	return distributions, err
+}
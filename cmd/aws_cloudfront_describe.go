@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/service/cloudfront" // Pacote para Amazon CloudFront
+	"github.com/spf13/cobra"                       // Pacote para criação de CLI usando Cobra
+)
+
+// cloudFrontDescribeCmd define o subcomando `cloudfront describe` que detalha uma distribuição
+var cloudFrontDescribeCmd = &cobra.Command{
+	Use:   "describe <distribution-id>",
+	Short: "Show aliases, origins, behaviors, certificate and WAF of a CloudFront distribution", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   describeCloudFront, // Função a ser executada quando o comando `cloudfront describe` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	CloudFrontCmd.AddCommand(cloudFrontDescribeCmd)
+}
+
+// newCloudFrontClient cria um cliente CloudFront usando a sessão global
+func newCloudFrontClient() (*cloudfront.CloudFront, error) {
+	sess, err := newGlobalSession()
+	if err != nil {
+		return nil, err
+	}
+	return cloudfront.New(sess), nil
+}
+
+// describeCloudFront exibe a configuração da distribuição como uma árvore
+func describeCloudFront(cmd *cobra.Command, args []string) {
+	cfClient, err := newCloudFrontClient()
+	if err != nil {
+		fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+		return
+	}
+
+	result, err := cfClient.GetDistribution(&cloudfront.GetDistributionInput{Id: aws.String(args[0])})
+	if err != nil {
+		fmt.Println("failed to get CloudFront distribution,", err)
+		return
+	}
+	distribution := result.Distribution
+	config := distribution.DistributionConfig
+
+	policies, err := cloudFrontPolicyNames(cfClient)
+	if err != nil {
+		fmt.Println("failed to list CloudFront policies,", err)
+		return
+	}
+
+	root := &treeNode{label: fmt.Sprintf("%s %s - %s (enabled: %s)", aws.StringValue(distribution.Id), aws.StringValue(distribution.DomainName),
+		aws.StringValue(distribution.Status), yesNo(aws.BoolValue(config.Enabled)))}
+
+	if comment := aws.StringValue(config.Comment); comment != "" {
+		root.add("Comment: %s", comment)
+	}
+
+	aliases := root.add("Aliases")
+	if config.Aliases != nil {
+		for _, alias := range config.Aliases.Items {
+			aliases.add("%s", aws.StringValue(alias))
+		}
+	}
+
+	origins := root.add("Origins")
+	if config.Origins != nil {
+		for _, origin := range config.Origins.Items {
+			node := origins.add("%s: %s (%s)", aws.StringValue(origin.Id), aws.StringValue(origin.DomainName), originType(origin))
+			if path := aws.StringValue(origin.OriginPath); path != "" {
+				node.add("Path: %s", path)
+			}
+			if custom := origin.CustomOriginConfig; custom != nil {
+				protocols := []string{}
+				if custom.OriginSslProtocols != nil {
+					protocols = aws.StringValueSlice(custom.OriginSslProtocols.Items)
+				}
+				node.add("Protocol policy: %s, TLS: %s", aws.StringValue(custom.OriginProtocolPolicy), strings.Join(protocols, ", "))
+			}
+			if shield := origin.OriginShield; shield != nil && aws.BoolValue(shield.Enabled) {
+				node.add("Origin Shield: %s", aws.StringValue(shield.OriginShieldRegion))
+			}
+		}
+	}
+	if config.OriginGroups != nil {
+		for _, group := range config.OriginGroups.Items {
+			members := []string{}
+			if group.Members != nil {
+				for _, member := range group.Members.Items {
+					members = append(members, aws.StringValue(member.OriginId))
+				}
+			}
+			origins.add("%s: origin group (failover %s)", aws.StringValue(group.Id), strings.Join(members, " -> "))
+		}
+	}
+
+	behaviors := root.add("Behaviors")
+	if config.CacheBehaviors != nil {
+		for _, behavior := range config.CacheBehaviors.Items { // Os behaviors são avaliados na ordem em que aparecem
+			node := behaviors.add("%s -> %s", aws.StringValue(behavior.PathPattern), aws.StringValue(behavior.TargetOriginId))
+			describeBehavior(node, policies, behavior.ViewerProtocolPolicy, behavior.CachePolicyId, behavior.OriginRequestPolicyId,
+				behavior.ResponseHeadersPolicyId, behavior.LambdaFunctionAssociations, behavior.FunctionAssociations)
+		}
+	}
+	if behavior := config.DefaultCacheBehavior; behavior != nil {
+		node := behaviors.add("Default (*) -> %s", aws.StringValue(behavior.TargetOriginId))
+		describeBehavior(node, policies, behavior.ViewerProtocolPolicy, behavior.CachePolicyId, behavior.OriginRequestPolicyId,
+			behavior.ResponseHeadersPolicyId, behavior.LambdaFunctionAssociations, behavior.FunctionAssociations)
+	}
+
+	if certificate := config.ViewerCertificate; certificate != nil {
+		node := root.add("Viewer certificate")
+		switch {
+		case aws.BoolValue(certificate.CloudFrontDefaultCertificate):
+			node.add("CloudFront default certificate (*.cloudfront.net)")
+		case certificate.ACMCertificateArn != nil:
+			node.add("ACM: %s", aws.StringValue(certificate.ACMCertificateArn))
+		case certificate.IAMCertificateId != nil:
+			node.add("IAM: %s", aws.StringValue(certificate.IAMCertificateId))
+		}
+		node.add("Minimum protocol: %s, SSL support: %s", aws.StringValue(certificate.MinimumProtocolVersion), aws.StringValue(certificate.SSLSupportMethod))
+	}
+
+	webACL := aws.StringValue(config.WebACLId)
+	if webACL == "" {
+		webACL = "none"
+	}
+	root.add("Web ACL: %s", webACL)
+	root.add("Price class: %s", aws.StringValue(config.PriceClass))
+	root.add("HTTP version: %s, IPv6: %s", aws.StringValue(config.HttpVersion), yesNo(aws.BoolValue(config.IsIPV6Enabled)))
+
+	if logging := config.Logging; logging != nil && aws.BoolValue(logging.Enabled) {
+		root.add("Logging: s3://%s/%s (cookies: %s)", strings.TrimSuffix(aws.StringValue(logging.Bucket), ".s3.amazonaws.com"),
+			aws.StringValue(logging.Prefix), yesNo(aws.BoolValue(logging.IncludeCookies)))
+	} else {
+		root.add("Logging: disabled")
+	}
+
+	printTree(root)
+}
+
+// describeBehavior adiciona ao nó as políticas e funções de um cache behavior; o behavior padrão
+// e os ordenados são tipos diferentes no SDK, por isso os campos são recebidos separadamente
+func describeBehavior(node *treeNode, policies map[string]string, viewerProtocolPolicy, cachePolicyID, originRequestPolicyID, responseHeadersPolicyID *string,
+	lambdaAssociations *cloudfront.LambdaFunctionAssociations, functionAssociations *cloudfront.FunctionAssociations) {
+	node.add("Viewer protocol: %s", aws.StringValue(viewerProtocolPolicy))
+
+	if id := aws.StringValue(cachePolicyID); id != "" {
+		node.add("Cache policy: %s", policyLabel(policies, id))
+	} else {
+		node.add("Cache policy: legacy cache settings")
+	}
+	if id := aws.StringValue(originRequestPolicyID); id != "" {
+		node.add("Origin request policy: %s", policyLabel(policies, id))
+	}
+	if id := aws.StringValue(responseHeadersPolicyID); id != "" {
+		node.add("Response headers policy: %s", policyLabel(policies, id))
+	}
+
+	if lambdaAssociations != nil {
+		for _, association := range lambdaAssociations.Items {
+			node.add("Lambda@Edge %s: %s", aws.StringValue(association.EventType), aws.StringValue(association.LambdaFunctionARN))
+		}
+	}
+	if functionAssociations != nil {
+		for _, association := range functionAssociations.Items {
+			node.add("CloudFront Function %s: %s", aws.StringValue(association.EventType), aws.StringValue(association.FunctionARN))
+		}
+	}
+}
+
+// originType classifica a origem em S3 (com OAC ou OAI), website S3 ou origem customizada
+func originType(origin *cloudfront.Origin) string {
+	domain := aws.StringValue(origin.DomainName)
+	switch {
+	case strings.Contains(domain, ".s3-website"):
+		return "S3 website"
+	case origin.S3OriginConfig != nil || strings.Contains(domain, ".s3."):
+		if aws.StringValue(origin.OriginAccessControlId) != "" {
+			return "S3, OAC " + aws.StringValue(origin.OriginAccessControlId)
+		}
+		if origin.S3OriginConfig != nil && aws.StringValue(origin.S3OriginConfig.OriginAccessIdentity) != "" {
+			return "S3, OAI " + strings.TrimPrefix(aws.StringValue(origin.S3OriginConfig.OriginAccessIdentity), "origin-access-identity/cloudfront/")
+		}
+		return "S3, public"
+	}
+	return "custom"
+}
+
+// cloudFrontPolicyNames retorna o nome das políticas de cache, de requisição à origem e de
+// cabeçalhos de resposta (gerenciadas e da conta), indexados pelo ID. As listas não têm
+// métodos de paginação no SDK, então cada uma é percorrida pelo NextMarker.
+func cloudFrontPolicyNames(cfClient *cloudfront.CloudFront) (map[string]string, error) {
+	names := map[string]string{}
+
+	cacheInput := &cloudfront.ListCachePoliciesInput{}
+	for {
+		result, err := cfClient.ListCachePolicies(cacheInput)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.CachePolicyList.Items {
+			names[aws.StringValue(item.CachePolicy.Id)] = aws.StringValue(item.CachePolicy.CachePolicyConfig.Name)
+		}
+		if aws.StringValue(result.CachePolicyList.NextMarker) == "" {
+			break
+		}
+		cacheInput.Marker = result.CachePolicyList.NextMarker // Próxima página
+	}
+
+	originRequestInput := &cloudfront.ListOriginRequestPoliciesInput{}
+	for {
+		result, err := cfClient.ListOriginRequestPolicies(originRequestInput)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.OriginRequestPolicyList.Items {
+			names[aws.StringValue(item.OriginRequestPolicy.Id)] = aws.StringValue(item.OriginRequestPolicy.OriginRequestPolicyConfig.Name)
+		}
+		if aws.StringValue(result.OriginRequestPolicyList.NextMarker) == "" {
+			break
+		}
+		originRequestInput.Marker = result.OriginRequestPolicyList.NextMarker // Próxima página
+	}
+
+	responseHeadersInput := &cloudfront.ListResponseHeadersPoliciesInput{}
+	for {
+		result, err := cfClient.ListResponseHeadersPolicies(responseHeadersInput)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range result.ResponseHeadersPolicyList.Items {
+			names[aws.StringValue(item.ResponseHeadersPolicy.Id)] = aws.StringValue(item.ResponseHeadersPolicy.ResponseHeadersPolicyConfig.Name)
+		}
+		if aws.StringValue(result.ResponseHeadersPolicyList.NextMarker) == "" {
+			break
+		}
+		responseHeadersInput.Marker = result.ResponseHeadersPolicyList.NextMarker // Próxima página
+	}
+	return names, nil
+}
+
+// policyLabel exibe o nome da política seguido do ID, ou apenas o ID se o nome não for conhecido
+func policyLabel(policies map[string]string, id string) string {
+	if name, ok := policies[id]; ok {
+		return fmt.Sprintf("%s (%s)", name, id)
+	}
+	return id
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/service/cloudfront" // Pacote para Amazon CloudFront
+	"github.com/olekukonko/tablewriter"            // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                       // Pacote para criação de CLI usando Cobra
+)
+
+// cloudFrontInvalidateCmd define o subcomando `cloudfront invalidate` que cria uma invalidação
+var cloudFrontInvalidateCmd = &cobra.Command{
+	Use:   "invalidate <distribution-id> <path>...",
+	Short: "Create a CloudFront invalidation and wait for it to complete", // Descrição breve do comando
+	Args:  cobra.MinimumNArgs(2),
+	Run:   invalidateCloudFront, // Função a ser executada quando o comando `cloudfront invalidate` é chamado
+}
+
+// cloudFrontInvalidationsCmd define o subcomando `cloudfront invalidations` que lista as invalidações recentes
+var cloudFrontInvalidationsCmd = &cobra.Command{
+	Use:   "invalidations <distribution-id>",
+	Short: "List recent CloudFront invalidations with status and paths", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   queryCloudFrontInvalidations, // Função a ser executada quando o comando `cloudfront invalidations` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	cloudFrontInvalidateCmd.Flags().Bool("no-wait", false, "Return right after creating the invalidation")
+	cloudFrontInvalidationsCmd.Flags().Int("limit", 10, "Number of invalidations to show")
+	CloudFrontCmd.AddCommand(cloudFrontInvalidateCmd, cloudFrontInvalidationsCmd)
+}
+
+// invalidateCloudFront cria a invalidação com uma caller reference única e aguarda sua conclusão;
+// encerra com código 1 em caso de falha para uso em scripts de deploy
+func invalidateCloudFront(cmd *cobra.Command, args []string) {
+	noWait, _ := cmd.Flags().GetBool("no-wait")
+	distributionID, paths := args[0], args[1:]
+
+	cfClient, err := newCloudFrontClient()
+	if err != nil {
+		fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+		os.Exit(1)
+	}
+
+	result, err := cfClient.CreateInvalidation(&cloudfront.CreateInvalidationInput{
+		DistributionId: aws.String(distributionID),
+		InvalidationBatch: &cloudfront.InvalidationBatch{
+			CallerReference: aws.String(fmt.Sprintf("lookr-%d", time.Now().UnixNano())), // Referências repetidas reaproveitam a invalidação anterior
+			Paths: &cloudfront.Paths{
+				Quantity: aws.Int64(int64(len(paths))),
+				Items:    aws.StringSlice(paths),
+			},
+		},
+	})
+	if err != nil {
+		fmt.Println("failed to create CloudFront invalidation,", err)
+		os.Exit(1)
+	}
+
+	invalidationID := aws.StringValue(result.Invalidation.Id)
+	fmt.Printf("Created invalidation %s for %d paths (%s)\n", invalidationID, len(paths), aws.StringValue(result.Invalidation.Status))
+	if noWait {
+		return
+	}
+
+	started := time.Now()
+	err = cfClient.WaitUntilInvalidationCompleted(&cloudfront.GetInvalidationInput{
+		DistributionId: aws.String(distributionID),
+		Id:             aws.String(invalidationID),
+	})
+	if err != nil {
+		fmt.Println("failed waiting for CloudFront invalidation,", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Invalidation %s completed in %s\n", invalidationID, time.Since(started).Round(time.Second))
+}
+
+// queryCloudFrontInvalidations lista as invalidações mais recentes com status e caminhos
+func queryCloudFrontInvalidations(cmd *cobra.Command, args []string) {
+	limit, _ := cmd.Flags().GetInt("limit")
+	distributionID := args[0]
+
+	if limit < 1 {
+		fmt.Println("--limit must be at least 1")
+		return
+	}
+
+	cfClient, err := newCloudFrontClient()
+	if err != nil {
+		fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+		return
+	}
+
+	result, err := cfClient.ListInvalidations(&cloudfront.ListInvalidationsInput{
+		DistributionId: aws.String(distributionID),
+		MaxItems:       aws.Int64(int64(limit)), // A API retorna as invalidações mais recentes primeiro
+	})
+	if err != nil {
+		fmt.Println("failed to list CloudFront invalidations,", err)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Invalidation ID", "Created", "Status", "Caller Reference", "Paths"}) // Define cabeçalhos da tabela
+	table.SetAutoWrapText(false)
+
+	for _, summary := range result.InvalidationList.Items {
+		invalidation, err := cfClient.GetInvalidation(&cloudfront.GetInvalidationInput{ // O resumo não inclui os caminhos
+			DistributionId: aws.String(distributionID),
+			Id:             summary.Id,
+		})
+		if err != nil {
+			fmt.Println("failed to get CloudFront invalidation,", err)
+			return
+		}
+
+		batch := invalidation.Invalidation.InvalidationBatch
+		paths := []string{}
+		if batch.Paths != nil {
+			paths = aws.StringValueSlice(batch.Paths.Items)
+		}
+
+		table.Append([]string{
+			aws.StringValue(summary.Id),
+			aws.TimeValue(summary.CreateTime).Format("2006-01-02 15:04:05"),
+			aws.StringValue(summary.Status),
+			aws.StringValue(batch.CallerReference),
+			strings.Join(paths, "\n"),
+		})
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
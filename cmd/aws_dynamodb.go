@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"lookr/deps" // Importação de pacotes locais ou dependências
 	"os"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -27,7 +28,7 @@ func init() {
 // queryDynamoDB é a função que executa a lógica para consultar tabelas DynamoDB
 func queryDynamoDB(cmd *cobra.Command, args []string) {
 	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
-	table.SetHeader([]string{"Table Name", "Region", "Status", "Item Count", "Size (Bytes)", "Provisioned Throughput", "arn"}) // Define cabeçalhos da tabela
+	table.SetHeader([]string{"Table Name", "Region", "Status", "Item Count", "Size (Bytes)", "Billing Mode", "Provisioned Throughput", "Indexes", "Stream", "TTL", "PITR", "Table Class", "Deletion Protection", "Replicas", "arn"}) // Define cabeçalhos da tabela
 
 	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
 	for _, region := range AuthRegions { // Itera sobre cada região autorizada
@@ -63,11 +64,58 @@ func queryDynamoDB(cmd *cobra.Command, args []string) {
 				return
 			}
 
+			billingMode := tableBillingMode(tableDetails.Table)
+
 			provisionedThroughput := ""
-			if tableDetails.Table.ProvisionedThroughput != nil { // Verifica se há throughput provisionado
-				provisionedThroughput = fmt.Sprintf("Read: %d, Write: %d",
-					*tableDetails.Table.ProvisionedThroughput.ReadCapacityUnits,
-					*tableDetails.Table.ProvisionedThroughput.WriteCapacityUnits) // Define o throughput provisionado
+			if billingMode == dynamodb.BillingModeProvisioned { // Tabelas on-demand retornam throughput 0/0
+				provisionedThroughput = formatThroughput(tableDetails.Table.ProvisionedThroughput) // Define o throughput provisionado
+			}
+
+			indexes := []string{}
+			for _, index := range tableDetails.Table.GlobalSecondaryIndexes { // GSIs têm throughput próprio
+				throughput := "on-demand"
+				if billingMode == dynamodb.BillingModeProvisioned {
+					throughput = formatThroughput(index.ProvisionedThroughput)
+				}
+				indexes = append(indexes, fmt.Sprintf("GSI %s (%s)", aws.StringValue(index.IndexName), throughput))
+			}
+			for _, index := range tableDetails.Table.LocalSecondaryIndexes { // LSIs compartilham o throughput da tabela
+				indexes = append(indexes, fmt.Sprintf("LSI %s", aws.StringValue(index.IndexName)))
+			}
+
+			stream := "Disabled"
+			if spec := tableDetails.Table.StreamSpecification; spec != nil && aws.BoolValue(spec.StreamEnabled) {
+				stream = aws.StringValue(spec.StreamViewType)
+			}
+
+			ttl, err := dynamoDBClient.DescribeTimeToLive(&dynamodb.DescribeTimeToLiveInput{TableName: tableName}) // Consulta o TTL da tabela
+			if err != nil {
+				fmt.Println("failed to describe DynamoDB time to live,", err)
+				return
+			}
+			ttlAttribute := aws.StringValue(ttl.TimeToLiveDescription.TimeToLiveStatus)
+			if ttl.TimeToLiveDescription.AttributeName != nil {
+				ttlAttribute = fmt.Sprintf("%s (%s)", *ttl.TimeToLiveDescription.AttributeName, ttlAttribute)
+			}
+
+			backups, err := dynamoDBClient.DescribeContinuousBackups(&dynamodb.DescribeContinuousBackupsInput{TableName: tableName}) // Consulta o PITR da tabela
+			if err != nil {
+				fmt.Println("failed to describe DynamoDB continuous backups,", err)
+				return
+			}
+			pitr := ""
+			if description := backups.ContinuousBackupsDescription.PointInTimeRecoveryDescription; description != nil {
+				pitr = aws.StringValue(description.PointInTimeRecoveryStatus)
+			}
+
+			tableClass := dynamodb.TableClassStandard // Tabelas sem resumo de classe usam a classe padrão
+			if tableDetails.Table.TableClassSummary != nil {
+				tableClass = aws.StringValue(tableDetails.Table.TableClassSummary.TableClass)
+			}
+
+			replicas := []string{}
+			for _, replica := range tableDetails.Table.Replicas { // Regiões das réplicas de tabela global
+				replicas = append(replicas, aws.StringValue(replica.RegionName))
 			}
 
 			// Cria uma linha com os detalhes da tabela para adicionar à tabela
@@ -77,7 +125,15 @@ func queryDynamoDB(cmd *cobra.Command, args []string) {
 				*tableDetails.Table.TableStatus,
 				fmt.Sprintf("%d", *tableDetails.Table.ItemCount),
 				fmt.Sprintf("%d", *tableDetails.Table.TableSizeBytes),
+				billingMode,
 				provisionedThroughput,
+				strings.Join(indexes, ", "),
+				stream,
+				ttlAttribute,
+				pitr,
+				tableClass,
+				yesNo(aws.BoolValue(tableDetails.Table.DeletionProtectionEnabled)),
+				strings.Join(replicas, ", "),
 				*tableDetails.Table.TableArn,
 			}
 			table.Append(row) // Adiciona a linha à tabela
@@ -85,3 +141,19 @@ func queryDynamoDB(cmd *cobra.Command, args []string) {
 	}
 	table.Render() // Renderiza a tabela com os resultados
 }
+
+// tableBillingMode retorna o modo de cobrança da tabela; tabelas antigas sem resumo são provisionadas
+func tableBillingMode(table *dynamodb.TableDescription) string {
+	if table.BillingModeSummary != nil && table.BillingModeSummary.BillingMode != nil {
+		return *table.BillingModeSummary.BillingMode
+	}
+	return dynamodb.BillingModeProvisioned
+}
+
+// formatThroughput formata a capacidade provisionada de leitura e escrita
+func formatThroughput(throughput *dynamodb.ProvisionedThroughputDescription) string {
+	if throughput == nil {
+		return ""
+	}
+	return fmt.Sprintf("Read: %d, Write: %d", aws.Int64Value(throughput.ReadCapacityUnits), aws.Int64Value(throughput.WriteCapacityUnits))
+}
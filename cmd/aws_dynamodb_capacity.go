@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"fmt"
+	"lookr/deps" // Importação de pacotes locais ou dependências
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch" // Pacote para Amazon CloudWatch
+	"github.com/aws/aws-sdk-go/service/dynamodb"   // Pacote para Amazon DynamoDB
+	"github.com/olekukonko/tablewriter"            // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                       // Pacote para criação de CLI usando Cobra
+)
+
+// Preços de referência da DynamoDB em us-east-1 (classe Standard), em dólares
+const (
+	rcuHourPrice      = 0.00013 // Por RCU provisionada por hora
+	wcuHourPrice      = 0.00065 // Por WCU provisionada por hora
+	readRequestPrice  = 0.125   // Por milhão de unidades de leitura on-demand
+	writeRequestPrice = 0.625   // Por milhão de unidades de escrita on-demand
+	hoursPerMonth     = 730
+	targetUtilization = 0.7 // Utilização alvo usada para dimensionar a capacidade recomendada
+)
+
+// dynamoDBCapacityCmd define o subcomando `dynamodb capacity` que compara capacidade provisionada e consumida
+var dynamoDBCapacityCmd = &cobra.Command{
+	Use:   "capacity",
+	Short: "Compare consumed and provisioned DynamoDB capacity and recommend a billing mode", // Descrição breve do comando
+	Run:   queryDynamoDBCapacity, // Função a ser executada quando o comando `dynamodb capacity` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	dynamoDBCapacityCmd.Flags().Int("days", 14, "Number of days of CloudWatch metrics to analyze")
+	DynamoDBCmd.AddCommand(dynamoDBCapacityCmd) // Adiciona o comando `capacity` como um subcomando de `dynamodb`
+}
+
+// capacityUsage resume as métricas de uma tabela ou GSI no período analisado
+type capacityUsage struct {
+	avgRead, avgWrite             float64 // Unidades consumidas por segundo, em média
+	peakRead, peakWrite           float64 // Maior média horária de unidades por segundo
+	readThrottles, writeThrottles float64
+}
+
+// queryDynamoDBCapacity é a função que executa a lógica do relatório de capacidade
+func queryDynamoDBCapacity(cmd *cobra.Command, args []string) {
+	days, _ := cmd.Flags().GetInt("days")
+	if days < 1 {
+		fmt.Println("--days must be at least 1") // Um período vazio tornaria as médias NaN ou negativas
+		return
+	}
+	window := time.Duration(days) * 24 * time.Hour
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Table / Index", "Region", "Billing Mode", "Provisioned R/W", "Avg Consumed R/W", "Peak R/W", "Utilization R/W", "Throttles R/W", "Monthly Cost", "Recommendation", "Estimated Cost"}) // Define cabeçalhos da tabela
+
+	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
+	for _, region := range AuthRegions { // Itera sobre cada região autorizada
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região atual
+		})
+
+		if err != nil {
+			fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+			return
+		}
+
+		dynamoDBClient := dynamodb.New(sess) // Cria um novo cliente DynamoDB com a sessão configurada
+		cwClient := cloudwatch.New(sess)     // Cria um novo cliente CloudWatch para as métricas de consumo
+
+		tableNames := []*string{}
+		err = dynamoDBClient.ListTablesPages(&dynamodb.ListTablesInput{}, func(page *dynamodb.ListTablesOutput, lastPage bool) bool {
+			tableNames = append(tableNames, page.TableNames...)
+			return true
+		})
+		if err != nil {
+			fmt.Println("failed to list Amazon DynamoDB tables,", err) // Imprime erro se a listagem falhar
+			return
+		}
+
+		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
+
+		for _, tableName := range tableNames { // Itera sobre cada nome de tabela listado
+			tableDetails, err := dynamoDBClient.DescribeTable(&dynamodb.DescribeTableInput{TableName: tableName})
+			if err != nil {
+				fmt.Println("failed to describe DynamoDB table,", err) // Imprime erro se a descrição falhar
+				return
+			}
+
+			billingMode := tableBillingMode(tableDetails.Table)
+
+			// A tabela e cada GSI são avaliados separadamente, pois têm capacidade própria
+			targets := map[string]*dynamodb.ProvisionedThroughputDescription{"": tableDetails.Table.ProvisionedThroughput}
+			for _, index := range tableDetails.Table.GlobalSecondaryIndexes {
+				targets[aws.StringValue(index.IndexName)] = index.ProvisionedThroughput
+			}
+
+			usage, err := capacityMetrics(cwClient, *tableName, targets, window)
+			if err != nil {
+				fmt.Println("failed to get DynamoDB metrics,", err)
+				return
+			}
+
+			for _, indexName := range sortedCapacityKeys(targets) {
+				name := *tableName
+				if indexName != "" {
+					name += " / " + indexName
+				}
+				table.Append(capacityRow(name, regionName, billingMode, targets[indexName], usage[indexName]))
+			}
+		}
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
+
+// capacityMetrics obtém com GetMetricData o consumo e os throttles da tabela e de cada GSI
+func capacityMetrics(cwClient *cloudwatch.CloudWatch, tableName string, targets map[string]*dynamodb.ProvisionedThroughputDescription, window time.Duration) (map[string]*capacityUsage, error) {
+	const period = 3600 // Pontos horários
+
+	metrics := []string{"ConsumedReadCapacityUnits", "ConsumedWriteCapacityUnits", "ReadThrottleEvents", "WriteThrottleEvents"}
+	queries := []*cloudwatch.MetricDataQuery{}
+	queryTargets := map[string][2]string{} // ID da consulta -> (índice, métrica)
+
+	i := 0
+	for indexName := range targets {
+		dimensions := []*cloudwatch.Dimension{{Name: aws.String("TableName"), Value: aws.String(tableName)}}
+		if indexName != "" {
+			dimensions = append(dimensions, &cloudwatch.Dimension{Name: aws.String("GlobalSecondaryIndexName"), Value: aws.String(indexName)})
+		}
+		for _, metric := range metrics {
+			id := fmt.Sprintf("m%d", i) // IDs devem começar com letra minúscula
+			i++
+			queryTargets[id] = [2]string{indexName, metric}
+			queries = append(queries, &cloudwatch.MetricDataQuery{
+				Id: aws.String(id),
+				MetricStat: &cloudwatch.MetricStat{
+					Metric: &cloudwatch.Metric{
+						Namespace:  aws.String("AWS/DynamoDB"),
+						MetricName: aws.String(metric),
+						Dimensions: dimensions,
+					},
+					Period: aws.Int64(period),
+					Stat:   aws.String(cloudwatch.StatisticSum),
+				},
+			})
+		}
+	}
+
+	usage := map[string]*capacityUsage{}
+	for indexName := range targets {
+		usage[indexName] = &capacityUsage{}
+	}
+
+	now := time.Now()
+	input := &cloudwatch.GetMetricDataInput{
+		MetricDataQueries: queries,
+		StartTime:         aws.Time(now.Add(-window)),
+		EndTime:           aws.Time(now),
+	}
+	totals := map[string]float64{}
+	err := cwClient.GetMetricDataPages(input, func(page *cloudwatch.GetMetricDataOutput, lastPage bool) bool {
+		for _, result := range page.MetricDataResults {
+			target := queryTargets[aws.StringValue(result.Id)]
+			current := usage[target[0]]
+			for _, value := range aws.Float64ValueSlice(result.Values) {
+				totals[aws.StringValue(result.Id)] += value
+				perSecond := value / period
+				switch target[1] {
+				case "ConsumedReadCapacityUnits":
+					current.peakRead = math.Max(current.peakRead, perSecond)
+				case "ConsumedWriteCapacityUnits":
+					current.peakWrite = math.Max(current.peakWrite, perSecond)
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for id, total := range totals { // Converte os totais do período em médias e contagens
+		target := queryTargets[id]
+		current := usage[target[0]]
+		switch target[1] {
+		case "ConsumedReadCapacityUnits":
+			current.avgRead = total / window.Seconds()
+		case "ConsumedWriteCapacityUnits":
+			current.avgWrite = total / window.Seconds()
+		case "ReadThrottleEvents":
+			current.readThrottles = total
+		case "WriteThrottleEvents":
+			current.writeThrottles = total
+		}
+	}
+	return usage, nil
+}
+
+// capacityRow monta a linha do relatório com custos mensais estimados e a recomendação
+// entre on-demand e provisionado (dimensionado para o pico com utilização alvo)
+func capacityRow(name, regionName, billingMode string, throughput *dynamodb.ProvisionedThroughputDescription, usage *capacityUsage) []string {
+	onDemandCost := (usage.avgRead*readRequestPrice + usage.avgWrite*writeRequestPrice) * hoursPerMonth * 3600 / 1e6
+
+	recommendedRead := math.Max(1, math.Ceil(usage.peakRead/targetUtilization))
+	recommendedWrite := math.Max(1, math.Ceil(usage.peakWrite/targetUtilization))
+	recommendedCost := (recommendedRead*rcuHourPrice + recommendedWrite*wcuHourPrice) * hoursPerMonth
+
+	provisioned, utilization, currentCost := "-", "-", onDemandCost
+	if billingMode == dynamodb.BillingModeProvisioned && throughput != nil {
+		read := float64(aws.Int64Value(throughput.ReadCapacityUnits))
+		write := float64(aws.Int64Value(throughput.WriteCapacityUnits))
+		provisioned = fmt.Sprintf("%.0f / %.0f", read, write)
+		utilization = fmt.Sprintf("%s / %s", percentage(usage.avgRead, read), percentage(usage.avgWrite, write))
+		currentCost = (read*rcuHourPrice + write*wcuHourPrice) * hoursPerMonth
+	}
+
+	recommendation, estimatedCost := "keep", currentCost
+	switch {
+	case onDemandCost < recommendedCost && onDemandCost < currentCost:
+		if billingMode != dynamodb.BillingModePayPerRequest {
+			recommendation = "switch to on-demand"
+		}
+		estimatedCost = onDemandCost
+	case recommendedCost < currentCost:
+		recommendation = fmt.Sprintf("provision %.0f / %.0f", recommendedRead, recommendedWrite)
+		estimatedCost = recommendedCost
+	}
+
+	return []string{
+		name,
+		regionName,
+		billingMode,
+		provisioned,
+		fmt.Sprintf("%.2f / %.2f", usage.avgRead, usage.avgWrite),
+		fmt.Sprintf("%.2f / %.2f", usage.peakRead, usage.peakWrite),
+		utilization,
+		fmt.Sprintf("%.0f / %.0f", usage.readThrottles, usage.writeThrottles),
+		fmt.Sprintf("$%.2f", currentCost),
+		recommendation,
+		fmt.Sprintf("$%.2f", estimatedCost),
+	}
+}
+
+// percentage formata `value` como porcentagem de `total`
+func percentage(value, total float64) string {
+	if total == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", value/total*100)
+}
+
+// sortedCapacityKeys retorna os nomes da tabela e dos índices em ordem, com a tabela ("") antes dos índices
+func sortedCapacityKeys(targets map[string]*dynamodb.ProvisionedThroughputDescription) []string {
+	keys := []string{}
+	for key := range targets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
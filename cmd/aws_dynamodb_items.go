@@ -0,0 +1,409 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"lookr/deps" // Importação de pacotes locais ou dependências
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb" // Pacote para Amazon DynamoDB
+	"github.com/olekukonko/tablewriter"          // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                     // Pacote para criação de CLI usando Cobra
+)
+
+// dynamoDBScanCmd define o subcomando `dynamodb scan` que lê os itens de uma tabela
+var dynamoDBScanCmd = &cobra.Command{
+	Use:   "scan <table>",
+	Short: "Scan items of a DynamoDB table", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   scanDynamoDB, // Função a ser executada quando o comando `dynamodb scan` é chamado
+}
+
+// dynamoDBQueryCmd define o subcomando `dynamodb query` que busca itens pela chave
+var dynamoDBQueryCmd = &cobra.Command{
+	Use:   "query <table>",
+	Short: "Query items of a DynamoDB table by key", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   queryDynamoDBItems, // Função a ser executada quando o comando `dynamodb query` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	for _, command := range []*cobra.Command{dynamoDBScanCmd, dynamoDBQueryCmd} {
+		command.Flags().String("projection", "", "Comma-separated list of attributes to return")
+		command.Flags().Int64("limit", 100, "Maximum number of items to return (0 for all)")
+		command.Flags().String("index", "", "Secondary index to read from")
+		command.Flags().StringP("output", "o", "table", "Output format: table or jsonl")
+		command.Flags().String("export", "", "Write the items as JSON Lines to this file")
+	}
+	dynamoDBScanCmd.Flags().Int64("segments", 1, "Number of parallel scan segments")
+	dynamoDBQueryCmd.Flags().String("key", "", "Partition key condition as name=value")
+	dynamoDBQueryCmd.Flags().String("sort-key", "", "Optional sort key condition as name=value")
+	dynamoDBQueryCmd.MarkFlagRequired("key")
+
+	DynamoDBCmd.AddCommand(dynamoDBScanCmd, dynamoDBQueryCmd)
+}
+
+// resolveDynamoDBTable procura a tabela nas regiões autorizadas e retorna o cliente da região onde ela existe
+func resolveDynamoDBTable(name string) (*dynamodb.DynamoDB, *dynamodb.TableDescription, error) {
+	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
+	for _, region := range AuthRegions { // Itera sobre cada região autorizada
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região atual
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		dynamoDBClient := dynamodb.New(sess) // Cria um novo cliente DynamoDB com a sessão configurada
+		result, err := dynamoDBClient.DescribeTable(&dynamodb.DescribeTableInput{TableName: aws.String(name)})
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeResourceNotFoundException {
+			continue // A tabela não existe nesta região
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return dynamoDBClient, result.Table, nil
+	}
+	return nil, nil, fmt.Errorf("table %s not found in any region", name)
+}
+
+// projectionExpression converte a lista de atributos em expressão com nomes substituídos,
+// evitando conflitos com palavras reservadas
+func projectionExpression(projection string) (*string, map[string]*string) {
+	if projection == "" {
+		return nil, nil
+	}
+
+	names := map[string]*string{}
+	placeholders := []string{}
+	for i, attribute := range strings.Split(projection, ",") {
+		placeholder := fmt.Sprintf("#p%d", i)
+		names[placeholder] = aws.String(strings.TrimSpace(attribute))
+		placeholders = append(placeholders, placeholder)
+	}
+	return aws.String(strings.Join(placeholders, ", ")), names
+}
+
+// scanDynamoDB lê a tabela, opcionalmente em segmentos paralelos, até o limite informado
+func scanDynamoDB(cmd *cobra.Command, args []string) {
+	projection, _ := cmd.Flags().GetString("projection")
+	limit, _ := cmd.Flags().GetInt64("limit")
+	index, _ := cmd.Flags().GetString("index")
+	segments, _ := cmd.Flags().GetInt64("segments")
+	output, _ := cmd.Flags().GetString("output")
+
+	if segments < 1 {
+		fmt.Println("--segments must be at least 1")
+		return
+	}
+	if limit < 0 {
+		fmt.Println("--limit must not be negative")
+		return
+	}
+	if !validItemOutput(output) {
+		fmt.Println("invalid output format, expected table or jsonl:", output)
+		return
+	}
+
+	dynamoDBClient, tableDescription, err := resolveDynamoDBTable(args[0])
+	if err != nil {
+		fmt.Println("failed to resolve DynamoDB table,", err)
+		return
+	}
+
+	expression, names := projectionExpression(projection)
+
+	var mutex sync.Mutex
+	var wait sync.WaitGroup
+	items := []map[string]*dynamodb.AttributeValue{}
+	errs := []error{}
+
+	for segment := int64(0); segment < segments; segment++ { // Cada segmento é lido por uma goroutine
+		input := &dynamodb.ScanInput{
+			TableName:                tableDescription.TableName,
+			ProjectionExpression:     expression,
+			ExpressionAttributeNames: names,
+		}
+		if index != "" {
+			input.IndexName = aws.String(index)
+		}
+		if limit > 0 {
+			input.Limit = aws.Int64(limit) // Evita ler páginas de 1 MB para limites pequenos
+		}
+		if segments > 1 {
+			input.Segment = aws.Int64(segment)
+			input.TotalSegments = aws.Int64(segments)
+		}
+
+		wait.Add(1)
+		go func(input *dynamodb.ScanInput) {
+			defer wait.Done()
+			err := dynamoDBClient.ScanPages(input, func(page *dynamodb.ScanOutput, lastPage bool) bool {
+				mutex.Lock()
+				defer mutex.Unlock()
+				items = append(items, page.Items...)
+				return limit == 0 || int64(len(items)) < limit // Interrompe a paginação ao atingir o limite
+			})
+			if err != nil {
+				mutex.Lock()
+				errs = append(errs, err)
+				mutex.Unlock()
+			}
+		}(input)
+	}
+	wait.Wait()
+
+	if len(errs) > 0 {
+		fmt.Println("failed to scan DynamoDB table,", errs[0])
+		return
+	}
+	printDynamoDBItems(cmd, tableDescription, items, limit)
+}
+
+// queryDynamoDBItems busca os itens pela chave de partição e, opcionalmente, pela chave de ordenação
+func queryDynamoDBItems(cmd *cobra.Command, args []string) {
+	projection, _ := cmd.Flags().GetString("projection")
+	limit, _ := cmd.Flags().GetInt64("limit")
+	index, _ := cmd.Flags().GetString("index")
+	key, _ := cmd.Flags().GetString("key")
+	sortKey, _ := cmd.Flags().GetString("sort-key")
+	output, _ := cmd.Flags().GetString("output")
+
+	if limit < 0 {
+		fmt.Println("--limit must not be negative")
+		return
+	}
+	if !validItemOutput(output) {
+		fmt.Println("invalid output format, expected table or jsonl:", output)
+		return
+	}
+
+	dynamoDBClient, tableDescription, err := resolveDynamoDBTable(args[0])
+	if err != nil {
+		fmt.Println("failed to resolve DynamoDB table,", err)
+		return
+	}
+
+	expression, names := projectionExpression(projection)
+	if names == nil {
+		names = map[string]*string{}
+	}
+	values := map[string]*dynamodb.AttributeValue{}
+	conditions := []string{}
+
+	for i, condition := range []string{key, sortKey} {
+		if condition == "" {
+			continue
+		}
+		parts := strings.SplitN(condition, "=", 2)
+		if len(parts) != 2 {
+			fmt.Println("invalid key condition, expected name=value:", condition)
+			return
+		}
+
+		names[fmt.Sprintf("#k%d", i)] = aws.String(parts[0])
+		values[fmt.Sprintf(":k%d", i)] = keyAttributeValue(tableDescription, parts[0], parts[1])
+		conditions = append(conditions, fmt.Sprintf("#k%d = :k%d", i, i))
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 tableDescription.TableName,
+		KeyConditionExpression:    aws.String(strings.Join(conditions, " AND ")),
+		ProjectionExpression:      expression,
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	}
+	if index != "" {
+		input.IndexName = aws.String(index)
+	}
+	if limit > 0 {
+		input.Limit = aws.Int64(limit) // Evita ler páginas de 1 MB para limites pequenos
+	}
+
+	items := []map[string]*dynamodb.AttributeValue{}
+	err = dynamoDBClient.QueryPages(input, func(page *dynamodb.QueryOutput, lastPage bool) bool {
+		items = append(items, page.Items...)
+		return limit == 0 || int64(len(items)) < limit // Interrompe a paginação ao atingir o limite
+	})
+	if err != nil {
+		fmt.Println("failed to query DynamoDB table,", err)
+		return
+	}
+	printDynamoDBItems(cmd, tableDescription, items, limit)
+}
+
+// keyAttributeValue cria o valor da chave usando o tipo declarado nas definições de atributos da tabela
+func keyAttributeValue(tableDescription *dynamodb.TableDescription, name, value string) *dynamodb.AttributeValue {
+	for _, definition := range tableDescription.AttributeDefinitions {
+		if aws.StringValue(definition.AttributeName) != name {
+			continue
+		}
+		switch aws.StringValue(definition.AttributeType) {
+		case dynamodb.ScalarAttributeTypeN:
+			return &dynamodb.AttributeValue{N: aws.String(value)}
+		case dynamodb.ScalarAttributeTypeB:
+			return &dynamodb.AttributeValue{B: []byte(value)}
+		}
+	}
+	return &dynamodb.AttributeValue{S: aws.String(value)}
+}
+
+// printDynamoDBItems exibe os itens como tabela ou JSON Lines e, com `--export`, grava-os em arquivo
+func printDynamoDBItems(cmd *cobra.Command, tableDescription *dynamodb.TableDescription, items []map[string]*dynamodb.AttributeValue, limit int64) {
+	output, _ := cmd.Flags().GetString("output")
+	export, _ := cmd.Flags().GetString("export")
+
+	if limit > 0 && int64(len(items)) > limit {
+		items = items[:limit]
+	}
+
+	documents := []map[string]interface{}{}
+	for _, item := range items {
+		documents = append(documents, attributeMapToJSON(item))
+	}
+
+	if export != "" {
+		file, err := os.Create(export)
+		if err != nil {
+			fmt.Println("failed to create export file,", err)
+			return
+		}
+		err = writeJSONLines(file, documents)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr // Falhas de gravação podem aparecer apenas no Close
+		}
+		if err != nil {
+			fmt.Println("failed to export items,", err)
+			return
+		}
+		fmt.Printf("exported %d items to %s\n", len(documents), export)
+		return
+	}
+
+	if output == "jsonl" {
+		if err := writeJSONLines(os.Stdout, documents); err != nil {
+			fmt.Println("failed to print items,", err)
+		}
+		return
+	}
+
+	columns := itemColumns(tableDescription, documents)
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader(columns) // Define cabeçalhos da tabela
+	for _, document := range documents {
+		row := []string{}
+		for _, column := range columns {
+			row = append(row, formatItemValue(document[column]))
+		}
+		table.Append(row) // Adiciona a linha à tabela
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
+
+// validItemOutput verifica se o formato de saída é um dos suportados
+func validItemOutput(output string) bool {
+	return output == "table" || output == "jsonl"
+}
+
+// itemColumns retorna as colunas da tabela: atributos da chave primeiro e os demais em ordem alfabética
+func itemColumns(tableDescription *dynamodb.TableDescription, documents []map[string]interface{}) []string {
+	columns := []string{}
+	seen := map[string]bool{}
+	for _, key := range tableDescription.KeySchema {
+		name := aws.StringValue(key.AttributeName)
+		columns = append(columns, name)
+		seen[name] = true
+	}
+
+	others := []string{}
+	for _, document := range documents {
+		for name := range document {
+			if !seen[name] {
+				seen[name] = true
+				others = append(others, name)
+			}
+		}
+	}
+	sort.Strings(others)
+	return append(columns, others...)
+}
+
+// formatItemValue exibe strings sem aspas e os demais valores como JSON
+func formatItemValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if text, ok := value.(string); ok {
+		return text
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
+
+// writeJSONLines grava um documento JSON por linha
+func writeJSONLines(writer io.Writer, documents []map[string]interface{}) error {
+	encoder := json.NewEncoder(writer)
+	for _, document := range documents {
+		if err := encoder.Encode(document); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attributeMapToJSON converte um item da DynamoDB em um mapa de valores JSON simples
+func attributeMapToJSON(item map[string]*dynamodb.AttributeValue) map[string]interface{} {
+	document := map[string]interface{}{}
+	for name, value := range item {
+		document[name] = attributeToJSON(value)
+	}
+	return document
+}
+
+// attributeToJSON converte um `dynamodb.AttributeValue` no valor JSON equivalente. Números são
+// mantidos como json.Number para não perder precisão e binários são codificados em base64.
+func attributeToJSON(value *dynamodb.AttributeValue) interface{} {
+	switch {
+	case value == nil || aws.BoolValue(value.NULL):
+		return nil
+	case value.S != nil:
+		return *value.S
+	case value.N != nil:
+		return json.Number(*value.N)
+	case value.B != nil:
+		return value.B
+	case value.BOOL != nil:
+		return *value.BOOL
+	case value.M != nil:
+		return attributeMapToJSON(value.M)
+	case value.L != nil:
+		list := []interface{}{}
+		for _, element := range value.L {
+			list = append(list, attributeToJSON(element))
+		}
+		return list
+	case value.SS != nil:
+		return aws.StringValueSlice(value.SS)
+	case value.NS != nil:
+		numbers := []json.Number{}
+		for _, number := range value.NS {
+			numbers = append(numbers, json.Number(aws.StringValue(number)))
+		}
+		return numbers
+	case value.BS != nil:
+		return value.BS
+	}
+	return nil
+}
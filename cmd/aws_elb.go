@@ -42,9 +42,7 @@ func queryELB(cmd *cobra.Command, args []string) {
 
 		elbv2Client := elbv2.New(sess) // Cria um novo cliente ELBv2 com a sessão configurada
 
-		input := &elbv2.DescribeLoadBalancersInput{} // Cria um input para descrever ELB Load Balancers
-
-		result, err := elbv2Client.DescribeLoadBalancers(input) // Descreve os ELB Load Balancers na região atual
+		loadBalancers, err := listLoadBalancers(elbv2Client) // Descreve os ELB Load Balancers na região atual
 		if err != nil {
 			fmt.Println("failed to describe ELB Load Balancers,", err) // Imprime erro se a descrição falhar
 			return
@@ -52,7 +50,7 @@ func queryELB(cmd *cobra.Command, args []string) {
 
 		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
 
-		for _, lb := range result.LoadBalancers { // Itera sobre cada ELB Load Balancer na lista de Load Balancers
+		for _, lb := range loadBalancers { // Itera sobre cada ELB Load Balancer na lista de Load Balancers
 			row := []string{
 				*lb.LoadBalancerName, // Nome do Load Balancer
 				regionName,           // Nome da região
@@ -67,3 +65,14 @@ func queryELB(cmd *cobra.Command, args []string) {
 	}
 	table.Render() // Renderiza a tabela com os resultados
 }
+
+// listLoadBalancers retorna todos os ELB Load Balancers da região do cliente
+func listLoadBalancers(elbv2Client *elbv2.ELBV2) ([]*elbv2.LoadBalancer, error) {
+	loadBalancers := []*elbv2.LoadBalancer{}
+	input := &elbv2.DescribeLoadBalancersInput{} // Cria um input para descrever ELB Load Balancers
+	err := elbv2Client.DescribeLoadBalancersPages(input, func(page *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
+		loadBalancers = append(loadBalancers, page.LoadBalancers...)
+		return true
+	})
+	return loadBalancers, err
+}
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"fmt"
+	"lookr/deps" // Importação de pacotes locais ou dependências
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elbv2" // Pacote para ELBv2 (Elastic Load Balancing)
+	"github.com/spf13/cobra"                  // Pacote para criação de CLI usando Cobra
+)
+
+// elbTargetsCmd define o subcomando `elb targets` que exibe listeners, regras e a saúde dos targets
+var elbTargetsCmd = &cobra.Command{
+	Use:   "targets",
+	Short: "Show listeners, rules, target groups and target health of each load balancer", // Descrição breve do comando
+	Run:   queryELBTargets, // Função a ser executada quando o comando `elb targets` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	ElbCmd.AddCommand(elbTargetsCmd) // Adiciona o comando `targets` como um subcomando de `elb`
+}
+
+// elbTargetWalker guarda o cliente e os target groups já consultados de uma região, para que
+// grupos usados por várias regras sejam descritos uma única vez
+type elbTargetWalker struct {
+	elbv2Client  *elbv2.ELBV2
+	targetGroups map[string]*elbv2.TargetGroup            // ARN -> target group
+	health       map[string][]*elbv2.TargetHealthDescription // ARN -> saúde dos targets
+}
+
+// queryELBTargets exibe cada load balancer como uma árvore de listeners, regras, target groups e targets
+func queryELBTargets(cmd *cobra.Command, args []string) {
+	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
+	for _, region := range AuthRegions { // Itera sobre cada região autorizada
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região atual
+		})
+
+		if err != nil {
+			fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+			return
+		}
+
+		walker := &elbTargetWalker{
+			elbv2Client:  elbv2.New(sess), // Cria um novo cliente ELBv2 com a sessão configurada
+			targetGroups: map[string]*elbv2.TargetGroup{},
+			health:       map[string][]*elbv2.TargetHealthDescription{},
+		}
+
+		loadBalancers, err := listLoadBalancers(walker.elbv2Client)
+		if err != nil {
+			fmt.Println("failed to describe ELB Load Balancers,", err) // Imprime erro se a descrição falhar
+			return
+		}
+
+		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
+
+		for _, lb := range loadBalancers {
+			state := ""
+			if lb.State != nil {
+				state = aws.StringValue(lb.State.Code)
+			}
+			root := &treeNode{label: fmt.Sprintf("%s [%s] %s %s - %s", aws.StringValue(lb.LoadBalancerName), regionName,
+				aws.StringValue(lb.Type), aws.StringValue(lb.Scheme), state)}
+
+			if err := walker.addListeners(root, lb); err != nil {
+				fmt.Println("failed to describe ELB listeners,", err)
+				return
+			}
+
+			printTree(root)
+			fmt.Println()
+		}
+	}
+}
+
+// addListeners adiciona os listeners do load balancer com certificados, regras e ações
+func (w *elbTargetWalker) addListeners(root *treeNode, lb *elbv2.LoadBalancer) error {
+	listeners := []*elbv2.Listener{}
+	input := &elbv2.DescribeListenersInput{LoadBalancerArn: lb.LoadBalancerArn}
+	err := w.elbv2Client.DescribeListenersPages(input, func(page *elbv2.DescribeListenersOutput, lastPage bool) bool {
+		listeners = append(listeners, page.Listeners...)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, listener := range listeners {
+		node := root.add("Listener %s:%d", aws.StringValue(listener.Protocol), aws.Int64Value(listener.Port))
+		if len(listener.Certificates) > 0 { // DescribeListeners retorna apenas o certificado padrão
+			certificates, err := w.listenerCertificates(listener.ListenerArn)
+			if err != nil {
+				return err
+			}
+			for _, certificate := range certificates {
+				if aws.BoolValue(certificate.IsDefault) {
+					node.add("Certificate: %s (default)", aws.StringValue(certificate.CertificateArn))
+				} else {
+					node.add("Certificate: %s (SNI)", aws.StringValue(certificate.CertificateArn))
+				}
+			}
+		}
+		if policy := aws.StringValue(listener.SslPolicy); policy != "" {
+			node.add("SSL policy: %s", policy)
+		}
+
+		if aws.StringValue(lb.Type) != elbv2.LoadBalancerTypeEnumApplication { // Apenas ALBs têm regras além da ação padrão
+			if err := w.addActions(node, "Default", listener.DefaultActions); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rules, err := w.listenerRules(listener.ListenerArn)
+		if err != nil {
+			return err
+		}
+		for _, rule := range rules { // Retornadas em ordem de prioridade, com a regra padrão por último
+			label := "Default"
+			if !aws.BoolValue(rule.IsDefault) {
+				label = fmt.Sprintf("Rule %s: %s", aws.StringValue(rule.Priority), ruleConditions(rule.Conditions))
+			}
+			if err := w.addActions(node, label, rule.Actions); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// listenerCertificates retorna o certificado padrão e os certificados SNI do listener, percorrendo as páginas
+func (w *elbTargetWalker) listenerCertificates(listenerArn *string) ([]*elbv2.Certificate, error) {
+	certificates := []*elbv2.Certificate{}
+	input := &elbv2.DescribeListenerCertificatesInput{ListenerArn: listenerArn}
+	for {
+		result, err := w.elbv2Client.DescribeListenerCertificates(input)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, result.Certificates...)
+
+		if aws.StringValue(result.NextMarker) == "" {
+			return certificates, nil
+		}
+		input.Marker = result.NextMarker // Próxima página
+	}
+}
+
+// listenerRules retorna todas as regras do listener, percorrendo as páginas
+func (w *elbTargetWalker) listenerRules(listenerArn *string) ([]*elbv2.Rule, error) {
+	rules := []*elbv2.Rule{}
+	input := &elbv2.DescribeRulesInput{ListenerArn: listenerArn}
+	for {
+		result, err := w.elbv2Client.DescribeRules(input)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, result.Rules...)
+
+		if aws.StringValue(result.NextMarker) == "" {
+			return rules, nil
+		}
+		input.Marker = result.NextMarker // Próxima página
+	}
+}
+
+// addActions adiciona um nó por ação; ações forward recebem os target groups e seus targets
+func (w *elbTargetWalker) addActions(parent *treeNode, label string, actions []*elbv2.Action) error {
+	for _, action := range actions {
+		switch aws.StringValue(action.Type) {
+		case elbv2.ActionTypeEnumForward:
+			node := parent.add("%s -> forward", label)
+
+			groups := []*elbv2.TargetGroupTuple{}
+			if action.ForwardConfig != nil {
+				groups = action.ForwardConfig.TargetGroups
+			}
+			if len(groups) == 0 && action.TargetGroupArn != nil { // Forma antiga, com um único target group
+				groups = append(groups, &elbv2.TargetGroupTuple{TargetGroupArn: action.TargetGroupArn})
+			}
+
+			for _, group := range groups {
+				if err := w.addTargetGroup(node, aws.StringValue(group.TargetGroupArn), aws.Int64Value(group.Weight), len(groups) > 1); err != nil {
+					return err
+				}
+			}
+		case elbv2.ActionTypeEnumRedirect:
+			redirect := action.RedirectConfig
+			parent.add("%s -> redirect %s %s://%s:%s%s", label, aws.StringValue(redirect.StatusCode), aws.StringValue(redirect.Protocol),
+				aws.StringValue(redirect.Host), aws.StringValue(redirect.Port), aws.StringValue(redirect.Path))
+		case elbv2.ActionTypeEnumFixedResponse:
+			parent.add("%s -> fixed response %s", label, aws.StringValue(action.FixedResponseConfig.StatusCode))
+		default: // Ações de autenticação antecedem o forward na mesma regra
+			parent.add("%s -> %s", label, aws.StringValue(action.Type))
+		}
+	}
+	return nil
+}
+
+// addTargetGroup adiciona o target group e a saúde de cada target, com o motivo quando não saudável
+func (w *elbTargetWalker) addTargetGroup(parent *treeNode, arn string, weight int64, weighted bool) error {
+	group, found := w.targetGroups[arn]
+	if !found {
+		result, err := w.elbv2Client.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{TargetGroupArns: []*string{aws.String(arn)}})
+		if err != nil {
+			return err
+		}
+		if len(result.TargetGroups) == 0 {
+			return fmt.Errorf("target group %s not found", arn)
+		}
+		group = result.TargetGroups[0]
+		w.targetGroups[arn] = group
+
+		health, err := w.elbv2Client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(arn)})
+		if err != nil {
+			return err
+		}
+		w.health[arn] = health.TargetHealthDescriptions
+	}
+
+	label := fmt.Sprintf("Target group %s (%s:%d, %s)", aws.StringValue(group.TargetGroupName), aws.StringValue(group.Protocol),
+		aws.Int64Value(group.Port), aws.StringValue(group.TargetType))
+	if weighted {
+		label += fmt.Sprintf(" weight %d", weight)
+	}
+	node := parent.add("%s", label)
+
+	if len(w.health[arn]) == 0 {
+		node.add("no registered targets")
+	}
+	for _, description := range w.health[arn] {
+		target := aws.StringValue(description.Target.Id)
+		if description.Target.Port != nil {
+			target += fmt.Sprintf(":%d", aws.Int64Value(description.Target.Port))
+		}
+		if zone := aws.StringValue(description.Target.AvailabilityZone); zone != "" {
+			target += " (" + zone + ")"
+		}
+
+		health := description.TargetHealth
+		state := aws.StringValue(health.State)
+		if reason := aws.StringValue(health.Reason); reason != "" {
+			state += fmt.Sprintf(" - %s: %s", reason, aws.StringValue(health.Description))
+		}
+		node.add("%s %s", target, state)
+	}
+	return nil
+}
+
+// ruleConditions resume as condições de uma regra, como `host-header=api.example.com path-pattern=/v1/*`
+func ruleConditions(conditions []*elbv2.RuleCondition) string {
+	parts := []string{}
+	for _, condition := range conditions {
+		field := aws.StringValue(condition.Field)
+		values := aws.StringValueSlice(condition.Values)
+
+		switch {
+		case condition.HostHeaderConfig != nil:
+			values = aws.StringValueSlice(condition.HostHeaderConfig.Values)
+		case condition.PathPatternConfig != nil:
+			values = aws.StringValueSlice(condition.PathPatternConfig.Values)
+		case condition.HttpRequestMethodConfig != nil:
+			values = aws.StringValueSlice(condition.HttpRequestMethodConfig.Values)
+		case condition.SourceIpConfig != nil:
+			values = aws.StringValueSlice(condition.SourceIpConfig.Values)
+		case condition.HttpHeaderConfig != nil:
+			field = "http-header " + aws.StringValue(condition.HttpHeaderConfig.HttpHeaderName)
+			values = aws.StringValueSlice(condition.HttpHeaderConfig.Values)
+		case condition.QueryStringConfig != nil:
+			values = []string{}
+			for _, pair := range condition.QueryStringConfig.Values {
+				values = append(values, aws.StringValue(pair.Key)+"="+aws.StringValue(pair.Value))
+			}
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s", field, strings.Join(values, ",")))
+	}
+	return strings.Join(parts, " ")
+}
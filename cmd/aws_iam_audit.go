@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/service/iam" // Pacote para IAM (Identity and Access Management)
+	"github.com/olekukonko/tablewriter"     // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                // Pacote para criação de CLI usando Cobra
+)
+
+// iamAuditCmd define o subcomando `iam audit` que procura políticas sem uso ou permissivas demais
+var iamAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Find unused and overly permissive IAM policies", // Descrição breve do comando
+	Run:   auditIAM, // Função a ser executada quando o comando `iam audit` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	iamAuditCmd.Flags().Int("unused-days", 90, "Report granted services not accessed for this many days")
+	iamAuditCmd.Flags().Bool("last-accessed", true, "Generate service last accessed details for users and roles")
+	IAMCmd.AddCommand(iamAuditCmd) // Adiciona o comando `audit` como um subcomando de `iam`
+}
+
+// auditIAM reúne os achados de políticas e principals da conta em uma única tabela
+func auditIAM(cmd *cobra.Command, args []string) {
+	unusedDays, _ := cmd.Flags().GetInt("unused-days")
+	lastAccessed, _ := cmd.Flags().GetBool("last-accessed")
+
+	if unusedDays < 1 {
+		fmt.Println("--unused-days must be at least 1")
+		return
+	}
+
+	sess, err := newGlobalSession()
+	if err != nil {
+		fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+		return
+	}
+	// Os relatórios de último acesso esbarram facilmente no limite de requisições do IAM; o
+	// retryer do SDK já espera com backoff exponencial em respostas de throttling
+	iamClient := iam.New(sess, aws.NewConfig().WithMaxRetries(iamAuditMaxRetries))
+
+	details := &iam.GetAccountAuthorizationDetailsOutput{} // Acumula todas as páginas
+	input := &iam.GetAccountAuthorizationDetailsInput{
+		Filter: aws.StringSlice([]string{iam.EntityTypeUser, iam.EntityTypeRole, iam.EntityTypeGroup, iam.EntityTypeLocalManagedPolicy}),
+	}
+	err = iamClient.GetAccountAuthorizationDetailsPages(input, func(page *iam.GetAccountAuthorizationDetailsOutput, lastPage bool) bool {
+		details.UserDetailList = append(details.UserDetailList, page.UserDetailList...)
+		details.RoleDetailList = append(details.RoleDetailList, page.RoleDetailList...)
+		details.GroupDetailList = append(details.GroupDetailList, page.GroupDetailList...)
+		details.Policies = append(details.Policies, page.Policies...)
+		return true
+	})
+	if err != nil {
+		fmt.Println("failed to get IAM account authorization details,", err)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Type", "Name", "Finding", "Details"}) // Define cabeçalhos da tabela
+
+	for _, policy := range details.Policies { // Políticas gerenciadas pela conta
+		if aws.Int64Value(policy.AttachmentCount) == 0 && aws.Int64Value(policy.PermissionsBoundaryUsageCount) == 0 {
+			table.Append([]string{"Policy", aws.StringValue(policy.PolicyName), "not attached", aws.StringValue(policy.Arn)})
+		}
+		for _, version := range policy.PolicyVersionList {
+			if !aws.BoolValue(version.IsDefaultVersion) {
+				continue
+			}
+			for _, finding := range permissiveFindings(aws.StringValue(version.Document)) {
+				table.Append([]string{"Policy", aws.StringValue(policy.PolicyName), finding, aws.StringValue(policy.Arn)})
+			}
+		}
+	}
+
+	for _, user := range details.UserDetailList {
+		if len(user.AttachedManagedPolicies) > 0 || len(user.UserPolicyList) > 0 {
+			names := []string{}
+			for _, policy := range user.AttachedManagedPolicies {
+				names = append(names, aws.StringValue(policy.PolicyName))
+			}
+			for _, policy := range user.UserPolicyList {
+				names = append(names, aws.StringValue(policy.PolicyName)+" (inline)")
+			}
+			table.Append([]string{"User", aws.StringValue(user.UserName), "policies attached directly instead of via groups", strings.Join(names, ", ")})
+		}
+		for _, policy := range user.UserPolicyList {
+			for _, finding := range permissiveFindings(aws.StringValue(policy.PolicyDocument)) {
+				table.Append([]string{"User", aws.StringValue(user.UserName), finding, aws.StringValue(policy.PolicyName) + " (inline)"})
+			}
+		}
+	}
+
+	for _, group := range details.GroupDetailList {
+		for _, policy := range group.GroupPolicyList {
+			for _, finding := range permissiveFindings(aws.StringValue(policy.PolicyDocument)) {
+				table.Append([]string{"Group", aws.StringValue(group.GroupName), finding, aws.StringValue(policy.PolicyName) + " (inline)"})
+			}
+		}
+	}
+
+	for _, role := range details.RoleDetailList {
+		for _, policy := range role.RolePolicyList {
+			for _, finding := range permissiveFindings(aws.StringValue(policy.PolicyDocument)) {
+				table.Append([]string{"Role", aws.StringValue(role.RoleName), finding, aws.StringValue(policy.PolicyName) + " (inline)"})
+			}
+		}
+	}
+
+	if lastAccessed {
+		principals := map[string][2]string{} // ARN -> (tipo, nome)
+		for _, user := range details.UserDetailList {
+			principals[aws.StringValue(user.Arn)] = [2]string{"User", aws.StringValue(user.UserName)}
+		}
+		for _, role := range details.RoleDetailList {
+			principals[aws.StringValue(role.Arn)] = [2]string{"Role", aws.StringValue(role.RoleName)}
+		}
+
+		unused, err := unusedServices(iamClient, principals, unusedDays)
+		if err != nil {
+			fmt.Println("failed to get service last accessed details,", err)
+			return
+		}
+		arns := []string{}
+		for arn := range unused {
+			arns = append(arns, arn)
+		}
+		sort.Strings(arns) // Ordem estável entre execuções
+
+		for _, arn := range arns {
+			principal := principals[arn]
+			services := unused[arn]
+			sort.Strings(services)
+			table.Append([]string{principal[0], principal[1], fmt.Sprintf("services unused for %d+ days", unusedDays), strings.Join(services, ", ")})
+		}
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
+
+// permissiveFindings procura statements Allow que concedem `*:*`, `iam:*` ou `iam:PassRole` em `*`.
+// NotAction concede tudo exceto as ações listadas e NotResource vale para todos os recursos exceto
+// os listados, por isso ambos são tratados como as formas mais permissivas.
+func permissiveFindings(document string) []string {
+	policy, err := parsePolicyDocument(document)
+	if err != nil {
+		return []string{"unparseable policy document: " + err.Error()}
+	}
+
+	findings := []string{}
+	seen := map[string]bool{}
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Allow" {
+			continue
+		}
+		resources := "*"
+		if len(statement.NotResource) > 0 {
+			resources = "all resources except " + strings.Join(statement.NotResource, ", ")
+		} else if !containsString(statement.Resource, "*") {
+			continue
+		}
+
+		actions := []string{}
+		for _, action := range statement.Action {
+			switch strings.ToLower(action) {
+			case "*", "*:*":
+				actions = append(actions, "*:*")
+			case "iam:*":
+				actions = append(actions, "iam:*")
+			case "iam:passrole":
+				actions = append(actions, "iam:PassRole")
+			}
+		}
+		if len(statement.NotAction) > 0 {
+			actions = append(actions, "all actions except "+strings.Join(statement.NotAction, ", "))
+		}
+
+		for _, action := range actions {
+			finding := fmt.Sprintf("grants %s on %s", action, resources)
+			if !seen[finding] {
+				seen[finding] = true
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings
+}
+
+// containsString verifica se a lista contém o valor
+func containsString(values []string, value string) bool {
+	for _, current := range values {
+		if current == value {
+			return true
+		}
+	}
+	return false
+}
+
+// lastAccessedConcurrency limita quantos relatórios de último acesso são gerados ao mesmo tempo
+const lastAccessedConcurrency = 5
+
+// iamAuditMaxRetries é o número de novas tentativas do cliente IAM em erros de throttling
+const iamAuditMaxRetries = 8
+
+// unusedServices gera os relatórios de último acesso de cada principal e retorna, por ARN,
+// os serviços concedidos que não foram acessados dentro do período. Os relatórios são gerados
+// em lotes de `lastAccessedConcurrency` para reduzir o throttling do IAM.
+func unusedServices(iamClient *iam.IAM, principals map[string][2]string, unusedDays int) (map[string][]string, error) {
+	arns := []string{}
+	for arn := range principals {
+		arns = append(arns, arn)
+	}
+	sort.Strings(arns)
+
+	unused := map[string][]string{}
+	for start := 0; start < len(arns); start += lastAccessedConcurrency {
+		end := start + lastAccessedConcurrency
+		if end > len(arns) {
+			end = len(arns)
+		}
+
+		jobs := map[string]string{} // ARN -> JobId
+		for _, arn := range arns[start:end] { // Os relatórios do lote são gerados em paralelo pela AWS
+			job, err := iamClient.GenerateServiceLastAccessedDetails(&iam.GenerateServiceLastAccessedDetailsInput{Arn: aws.String(arn)})
+			if err != nil {
+				return nil, err
+			}
+			jobs[arn] = aws.StringValue(job.JobId)
+		}
+
+		for arn, jobID := range jobs {
+			services, err := serviceLastAccessed(iamClient, arn, jobID)
+			if err != nil {
+				return nil, err
+			}
+			for _, service := range services {
+				if service.LastAuthenticated == nil || daysSince(*service.LastAuthenticated) > unusedDays {
+					unused[arn] = append(unused[arn], aws.StringValue(service.ServiceNamespace))
+				}
+			}
+		}
+	}
+	return unused, nil
+}
+
+// serviceLastAccessed aguarda a conclusão do relatório e retorna todas as suas páginas
+func serviceLastAccessed(iamClient *iam.IAM, arn string, jobID string) ([]*iam.ServiceLastAccessed, error) {
+	services := []*iam.ServiceLastAccessed{}
+	input := &iam.GetServiceLastAccessedDetailsInput{JobId: aws.String(jobID)}
+	for {
+		result, err := iamClient.GetServiceLastAccessedDetails(input)
+		if err != nil {
+			return nil, err
+		}
+
+		switch aws.StringValue(result.JobStatus) {
+		case iam.JobStatusTypeInProgress:
+			time.Sleep(2 * time.Second)
+			continue
+		case iam.JobStatusTypeFailed:
+			return nil, fmt.Errorf("service last accessed job for %s failed", arn)
+		}
+
+		services = append(services, result.ServicesLastAccessed...)
+		if !aws.BoolValue(result.IsTruncated) {
+			return services, nil
+		}
+		input.Marker = result.Marker // Próxima página do relatório
+	}
+}
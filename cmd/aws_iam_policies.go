@@ -0,0 +1,354 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam" // Pacote para IAM (Identity and Access Management)
+	"github.com/aws/aws-sdk-go/service/sts" // Pacote para AWS STS
+	"github.com/olekukonko/tablewriter"     // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                // Pacote para criação de CLI usando Cobra
+)
+
+// iamPoliciesCmd define o subcomando `iam policies` que mostra as permissões efetivas de um principal
+var iamPoliciesCmd = &cobra.Command{
+	Use:   "policies <user-or-role>",
+	Short: "Show the merged policy statements that apply to an IAM user or role", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   queryIAMPolicies, // Função a ser executada quando o comando `iam policies` é chamado
+}
+
+// iamCanCmd define o subcomando `iam can` que simula uma ação para um principal
+var iamCanCmd = &cobra.Command{
+	Use:   "can <user-or-role> <action> <resource>",
+	Short: "Simulate whether an IAM user or role can perform an action on a resource", // Descrição breve do comando
+	Args:  cobra.ExactArgs(3),
+	Run:   simulateIAMPrincipal, // Função a ser executada quando o comando `iam can` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	IAMCmd.AddCommand(iamPoliciesCmd, iamCanCmd)
+}
+
+// iamPrincipal identifica um usuário ou role IAM
+type iamPrincipal struct {
+	kind string // "user" ou "role"
+	name string
+	arn  string
+}
+
+// sourcedPolicy é um documento de política com a indicação de onde ele veio
+type sourcedPolicy struct {
+	source   string
+	document *policyDocument
+}
+
+// resolveIAMPrincipal aceita o nome ou ARN de um usuário ou role e retorna seu tipo e ARN.
+// Um nome usado por um usuário e por um role ao mesmo tempo exige o ARN.
+func resolveIAMPrincipal(iamClient *iam.IAM, principal string) (*iamPrincipal, error) {
+	if strings.HasPrefix(principal, "arn:") { // O nome só é procurado na conta das credenciais
+		if err := checkPrincipalAccount(principal); err != nil {
+			return nil, err
+		}
+	}
+	name := principal[strings.LastIndex(principal, "/")+1:] // ARNs terminam com path/nome
+
+	var user, role *iamPrincipal
+	if !strings.Contains(principal, ":role/") {
+		result, err := iamClient.GetUser(&iam.GetUserInput{UserName: aws.String(name)})
+		if err == nil {
+			user = &iamPrincipal{kind: "user", name: name, arn: aws.StringValue(result.User.Arn)}
+		} else if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != iam.ErrCodeNoSuchEntityException {
+			return nil, err
+		}
+	}
+	if !strings.Contains(principal, ":user/") {
+		result, err := iamClient.GetRole(&iam.GetRoleInput{RoleName: aws.String(name)})
+		if err == nil {
+			role = &iamPrincipal{kind: "role", name: name, arn: aws.StringValue(result.Role.Arn)}
+		} else if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != iam.ErrCodeNoSuchEntityException {
+			return nil, err
+		}
+	}
+
+	switch {
+	case user != nil && role != nil:
+		return nil, fmt.Errorf("both an IAM user and a role are named %s, use the ARN instead (%s or %s)", name, user.arn, role.arn)
+	case user != nil:
+		return user, nil
+	case role != nil:
+		return role, nil
+	}
+	return nil, fmt.Errorf("no IAM user or role named %s", name)
+}
+
+// checkPrincipalAccount rejeita ARNs de outra conta, que seriam resolvidos pelo nome na conta atual
+func checkPrincipalAccount(principalArn string) error {
+	parts := strings.SplitN(principalArn, ":", 6)
+	if len(parts) < 6 {
+		return fmt.Errorf("invalid ARN %s", principalArn)
+	}
+
+	sess, err := newGlobalSession()
+	if err != nil {
+		return err
+	}
+	identity, err := sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return err
+	}
+	if account := aws.StringValue(identity.Account); parts[4] != account {
+		return fmt.Errorf("%s belongs to account %s, but the credentials are for account %s", principalArn, parts[4], account)
+	}
+	return nil
+}
+
+// managedPolicyDocument obtém a versão padrão de uma política gerenciada
+func managedPolicyDocument(iamClient *iam.IAM, policyArn *string) (*policyDocument, error) {
+	policy, err := iamClient.GetPolicy(&iam.GetPolicyInput{PolicyArn: policyArn})
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := iamClient.GetPolicyVersion(&iam.GetPolicyVersionInput{
+		PolicyArn: policyArn,
+		VersionId: policy.Policy.DefaultVersionId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parsePolicyDocument(aws.StringValue(version.PolicyVersion.Document))
+}
+
+// appendManagedPolicies adiciona os documentos das políticas gerenciadas anexadas
+func appendManagedPolicies(iamClient *iam.IAM, policies []sourcedPolicy, attached []*iam.AttachedPolicy, origin string) ([]sourcedPolicy, error) {
+	for _, policy := range attached {
+		document, err := managedPolicyDocument(iamClient, policy.PolicyArn)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, sourcedPolicy{source: fmt.Sprintf("%s (managed, %s)", aws.StringValue(policy.PolicyName), origin), document: document})
+	}
+	return policies, nil
+}
+
+// appendInlinePolicies obtém cada política inline com `get` e adiciona seu documento
+func appendInlinePolicies(policies []sourcedPolicy, names []*string, origin string, get func(policyName *string) (string, error)) ([]sourcedPolicy, error) {
+	for _, policyName := range names {
+		encoded, err := get(policyName)
+		if err != nil {
+			return nil, err
+		}
+		document, err := parsePolicyDocument(encoded)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, sourcedPolicy{source: fmt.Sprintf("%s (inline, %s)", aws.StringValue(policyName), origin), document: document})
+	}
+	return policies, nil
+}
+
+// principalPolicies reúne as políticas gerenciadas, inline e herdadas de grupos do principal
+func principalPolicies(iamClient *iam.IAM, principal *iamPrincipal) ([]sourcedPolicy, error) {
+	policies := []sourcedPolicy{}
+	name := aws.String(principal.name)
+
+	if principal.kind == "role" {
+		attached := []*iam.AttachedPolicy{}
+		err := iamClient.ListAttachedRolePoliciesPages(&iam.ListAttachedRolePoliciesInput{RoleName: name}, func(page *iam.ListAttachedRolePoliciesOutput, lastPage bool) bool {
+			attached = append(attached, page.AttachedPolicies...)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		if policies, err = appendManagedPolicies(iamClient, policies, attached, "role"); err != nil {
+			return nil, err
+		}
+
+		inline := []*string{}
+		err = iamClient.ListRolePoliciesPages(&iam.ListRolePoliciesInput{RoleName: name}, func(page *iam.ListRolePoliciesOutput, lastPage bool) bool {
+			inline = append(inline, page.PolicyNames...)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		return appendInlinePolicies(policies, inline, "role", func(policyName *string) (string, error) {
+			policy, err := iamClient.GetRolePolicy(&iam.GetRolePolicyInput{RoleName: name, PolicyName: policyName})
+			if err != nil {
+				return "", err
+			}
+			return aws.StringValue(policy.PolicyDocument), nil
+		})
+	}
+
+	attached := []*iam.AttachedPolicy{}
+	err := iamClient.ListAttachedUserPoliciesPages(&iam.ListAttachedUserPoliciesInput{UserName: name}, func(page *iam.ListAttachedUserPoliciesOutput, lastPage bool) bool {
+		attached = append(attached, page.AttachedPolicies...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if policies, err = appendManagedPolicies(iamClient, policies, attached, "user"); err != nil {
+		return nil, err
+	}
+
+	inline := []*string{}
+	err = iamClient.ListUserPoliciesPages(&iam.ListUserPoliciesInput{UserName: name}, func(page *iam.ListUserPoliciesOutput, lastPage bool) bool {
+		inline = append(inline, page.PolicyNames...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	policies, err = appendInlinePolicies(policies, inline, "user", func(policyName *string) (string, error) {
+		policy, err := iamClient.GetUserPolicy(&iam.GetUserPolicyInput{UserName: name, PolicyName: policyName})
+		if err != nil {
+			return "", err
+		}
+		return aws.StringValue(policy.PolicyDocument), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []*iam.Group{}
+	err = iamClient.ListGroupsForUserPages(&iam.ListGroupsForUserInput{UserName: name}, func(page *iam.ListGroupsForUserOutput, lastPage bool) bool {
+		groups = append(groups, page.Groups...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups { // Políticas herdadas dos grupos do usuário
+		origin := "group " + aws.StringValue(group.GroupName)
+
+		attached := []*iam.AttachedPolicy{}
+		err := iamClient.ListAttachedGroupPoliciesPages(&iam.ListAttachedGroupPoliciesInput{GroupName: group.GroupName}, func(page *iam.ListAttachedGroupPoliciesOutput, lastPage bool) bool {
+			attached = append(attached, page.AttachedPolicies...)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		if policies, err = appendManagedPolicies(iamClient, policies, attached, origin); err != nil {
+			return nil, err
+		}
+
+		inline := []*string{}
+		err = iamClient.ListGroupPoliciesPages(&iam.ListGroupPoliciesInput{GroupName: group.GroupName}, func(page *iam.ListGroupPoliciesOutput, lastPage bool) bool {
+			inline = append(inline, page.PolicyNames...)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		policies, err = appendInlinePolicies(policies, inline, origin, func(policyName *string) (string, error) {
+			policy, err := iamClient.GetGroupPolicy(&iam.GetGroupPolicyInput{GroupName: group.GroupName, PolicyName: policyName})
+			if err != nil {
+				return "", err
+			}
+			return aws.StringValue(policy.PolicyDocument), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return policies, nil
+}
+
+// queryIAMPolicies exibe todos os statements que se aplicam ao principal, um por linha
+func queryIAMPolicies(cmd *cobra.Command, args []string) {
+	iamClient, err := newIAMClient()
+	if err != nil {
+		fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+		return
+	}
+
+	principal, err := resolveIAMPrincipal(iamClient, args[0])
+	if err != nil {
+		fmt.Println("failed to resolve IAM principal,", err)
+		return
+	}
+
+	policies, err := principalPolicies(iamClient, principal)
+	if err != nil {
+		fmt.Println("failed to collect IAM policies,", err)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Policy", "Effect", "Actions", "Resources", "Condition"}) // Define cabeçalhos da tabela
+	table.SetAutoWrapText(false)
+	table.SetRowLine(true)
+
+	for _, policy := range policies {
+		for _, statement := range policy.document.Statement {
+			actions := strings.Join(statement.Action, "\n")
+			if len(statement.NotAction) > 0 {
+				actions = "NOT " + strings.Join(statement.NotAction, "\nNOT ")
+			}
+			resources := strings.Join(statement.Resource, "\n")
+			if len(statement.NotResource) > 0 {
+				resources = "NOT " + strings.Join(statement.NotResource, "\nNOT ")
+			}
+
+			condition := ""
+			if len(statement.Condition) > 0 {
+				encoded, err := json.Marshal(statement.Condition)
+				if err != nil {
+					fmt.Println("failed to encode policy condition,", err)
+					return
+				}
+				condition = string(encoded)
+			}
+
+			table.Append([]string{policy.source, statement.Effect, actions, resources, condition})
+		}
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
+
+// simulateIAMPrincipal usa SimulatePrincipalPolicy para responder se o principal pode executar a ação
+func simulateIAMPrincipal(cmd *cobra.Command, args []string) {
+	iamClient, err := newIAMClient()
+	if err != nil {
+		fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+		return
+	}
+
+	principal, err := resolveIAMPrincipal(iamClient, args[0])
+	if err != nil {
+		fmt.Println("failed to resolve IAM principal,", err)
+		return
+	}
+
+	result, err := iamClient.SimulatePrincipalPolicy(&iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principal.arn),
+		ActionNames:     []*string{aws.String(args[1])},
+		ResourceArns:    []*string{aws.String(args[2])},
+	})
+	if err != nil {
+		fmt.Println("failed to simulate IAM principal policy,", err)
+		return
+	}
+
+	for _, evaluation := range result.EvaluationResults {
+		fmt.Printf("%s %s on %s: %s\n", principal.name, aws.StringValue(evaluation.EvalActionName),
+			aws.StringValue(evaluation.EvalResourceName), aws.StringValue(evaluation.EvalDecision))
+
+		for _, statement := range evaluation.MatchedStatements {
+			fmt.Println("  matched:", aws.StringValue(statement.SourcePolicyId))
+		}
+		if len(evaluation.MissingContextValues) > 0 {
+			fmt.Println("  missing context keys:", strings.Join(aws.StringValueSlice(evaluation.MissingContextValues), ", "))
+		}
+	}
+}
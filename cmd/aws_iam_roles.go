@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/service/iam" // Pacote para IAM (Identity and Access Management)
+	"github.com/aws/aws-sdk-go/service/sts" // Pacote para AWS STS
+	"github.com/olekukonko/tablewriter"     // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                // Pacote para criação de CLI usando Cobra
+)
+
+// iamRolesCmd define o subcomando `iam roles` que lista roles e analisa suas políticas de confiança
+var iamRolesCmd = &cobra.Command{
+	Use:   "roles",
+	Short: "List IAM roles and analyze their trust policies with --trust", // Descrição breve do comando
+	Run:   queryIAMRoles, // Função a ser executada quando o comando `iam roles` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	iamRolesCmd.Flags().Bool("trust", false, "Show trusted principals and flag cross-account exposure")
+	iamRolesCmd.Flags().Int("unused-days", 90, "Flag roles not used for this many days")
+	IAMCmd.AddCommand(iamRolesCmd) // Adiciona o comando `roles` como um subcomando de `iam`
+}
+
+// accountIDPattern identifica IDs de conta em principals AWS (ARN ou ID puro)
+var accountIDPattern = regexp.MustCompile(`^(?:arn:aws[a-z-]*:iam::)?(\d{12})(?::|$)`)
+
+// queryIAMRoles lista os roles com a última utilização e, com `--trust`, os principals confiáveis
+func queryIAMRoles(cmd *cobra.Command, args []string) {
+	trust, _ := cmd.Flags().GetBool("trust")
+	unusedDays, _ := cmd.Flags().GetInt("unused-days")
+
+	sess, err := newGlobalSession()
+	if err != nil {
+		fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+		return
+	}
+	iamClient := iam.New(sess) // Cria um novo cliente IAM com a sessão configurada
+
+	identity, err := sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{}) // Conta atual, para detectar contas externas
+	if err != nil {
+		fmt.Println("failed to get caller identity,", err)
+		return
+	}
+	accountID := aws.StringValue(identity.Account)
+
+	roles := []*iam.Role{}
+	err = iamClient.ListRolesPages(&iam.ListRolesInput{}, func(page *iam.ListRolesOutput, lastPage bool) bool {
+		roles = append(roles, page.Roles...)
+		return true
+	})
+	if err != nil {
+		fmt.Println("failed to list IAM roles,", err) // Imprime erro se a listagem de roles falhar
+		return
+	}
+
+	header := []string{"Role Name", "Creation Time", "Last Used", "Last Used Region"}
+	if trust {
+		header = append(header, "Trusted Principals", "Flags")
+	}
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader(header) // Define cabeçalhos da tabela
+
+	for _, role := range roles {
+		details, err := iamClient.GetRole(&iam.GetRoleInput{RoleName: role.RoleName}) // ListRoles não retorna RoleLastUsed
+		if err != nil {
+			fmt.Println("failed to get IAM role,", err)
+			return
+		}
+
+		lastUsed, lastUsedRegion := "never", ""
+		flags := []string{}
+		if used := details.Role.RoleLastUsed; used != nil && used.LastUsedDate != nil {
+			lastUsed = used.LastUsedDate.Format("2006-01-02")
+			lastUsedRegion = aws.StringValue(used.Region)
+			if daysSince(*used.LastUsedDate) > unusedDays {
+				flags = append(flags, "stale")
+			}
+		} else if daysSince(aws.TimeValue(role.CreateDate)) > unusedDays {
+			flags = append(flags, "stale")
+		}
+
+		row := []string{
+			aws.StringValue(role.RoleName),
+			role.CreateDate.String(),
+			lastUsed,
+			lastUsedRegion,
+		}
+
+		if trust {
+			principals, trustFlags, err := analyzeTrustPolicy(aws.StringValue(role.AssumeRolePolicyDocument), accountID)
+			if err != nil {
+				fmt.Println("failed to parse trust policy,", err)
+				return
+			}
+			row = append(row, strings.Join(principals, "\n"), strings.Join(append(trustFlags, flags...), ", "))
+		}
+		table.Append(row) // Adiciona a linha à tabela
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
+
+// analyzeTrustPolicy lista os principals confiáveis do role e sinaliza contas externas,
+// principals curinga e provedores OIDC sem condição sobre o `sub`
+func analyzeTrustPolicy(document string, accountID string) ([]string, []string, error) {
+	policy, err := parsePolicyDocument(document)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	principals := []string{}
+	flags := map[string]bool{}
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Allow" {
+			continue
+		}
+
+		for kind, values := range statement.Principal {
+			for _, value := range values {
+				principals = append(principals, fmt.Sprintf("%s: %s", kind, value))
+
+				switch {
+				case value == "*":
+					flags["wildcard principal"] = true
+				case kind == "AWS":
+					if match := accountIDPattern.FindStringSubmatch(value); match != nil && match[1] != accountID {
+						flags["external account "+match[1]] = true
+					}
+				case kind == "Federated" && strings.Contains(value, "oidc-provider/") && !hasSubCondition(statement.Condition):
+					flags["OIDC without sub condition"] = true
+				}
+			}
+		}
+	}
+	sort.Strings(principals)
+
+	result := []string{}
+	for flag := range flags {
+		result = append(result, flag)
+	}
+	sort.Strings(result)
+	return principals, result, nil
+}
+
+// hasSubCondition verifica se alguma condição restringe a chave `<provedor>:sub`
+func hasSubCondition(conditions map[string]map[string]stringList) bool {
+	for _, keys := range conditions {
+		for key := range keys {
+			if strings.HasSuffix(key, ":sub") {
+				return true
+			}
+		}
+	}
+	return false
+}
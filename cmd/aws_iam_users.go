@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/service/iam" // Pacote para IAM (Identity and Access Management)
+	"github.com/olekukonko/tablewriter"     // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                // Pacote para criação de CLI usando Cobra
+)
+
+// iamUsersCmd define o subcomando `iam users` que lista usuários e audita suas credenciais
+var iamUsersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "List IAM users and audit their credentials with --audit", // Descrição breve do comando
+	Run:   queryIAMUsers, // Função a ser executada quando o comando `iam users` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	iamUsersCmd.Flags().Bool("audit", false, "Build the credential report and flag risky credentials")
+	iamUsersCmd.Flags().Int("key-age", 90, "Flag access keys older than this many days")
+	iamUsersCmd.Flags().Int("unused-days", 90, "Flag credentials not used for this many days")
+	IAMCmd.AddCommand(iamUsersCmd) // Adiciona o comando `users` como um subcomando de `iam`
+}
+
+// newIAMClient cria um cliente IAM usando a sessão global
+func newIAMClient() (*iam.IAM, error) {
+	sess, err := newGlobalSession()
+	if err != nil {
+		return nil, err
+	}
+	return iam.New(sess), nil
+}
+
+// queryIAMUsers lista os usuários IAM ou, com `--audit`, o relatório de credenciais
+func queryIAMUsers(cmd *cobra.Command, args []string) {
+	audit, _ := cmd.Flags().GetBool("audit")
+
+	iamClient, err := newIAMClient()
+	if err != nil {
+		fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+		return
+	}
+
+	if audit {
+		auditIAMUsers(cmd, iamClient)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"User Name", "Creation Time", "Password Last Used", "ARN"}) // Define cabeçalhos da tabela
+
+	err = iamClient.ListUsersPages(&iam.ListUsersInput{}, func(page *iam.ListUsersOutput, lastPage bool) bool {
+		for _, user := range page.Users {
+			passwordLastUsed := ""
+			if user.PasswordLastUsed != nil {
+				passwordLastUsed = user.PasswordLastUsed.String()
+			}
+			row := []string{
+				aws.StringValue(user.UserName),
+				user.CreateDate.String(),
+				passwordLastUsed,
+				aws.StringValue(user.Arn),
+			}
+			table.Append(row) // Adiciona a linha à tabela
+		}
+		return true
+	})
+	if err != nil {
+		fmt.Println("failed to list IAM users,", err) // Imprime erro se a listagem de users falhar
+		return
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
+
+// credentialReportAttempts limita as consultas à geração do relatório de credenciais (cerca de 2 minutos)
+const credentialReportAttempts = 60
+
+// credentialReport gera o relatório de credenciais da conta, aguardando sua conclusão,
+// e retorna cada linha do CSV como um mapa indexado pelo cabeçalho
+func credentialReport(iamClient *iam.IAM) ([]map[string]string, error) {
+	for attempt := 1; ; attempt++ { // A geração é assíncrona e deve ser consultada até ficar completa
+		generated, err := iamClient.GenerateCredentialReport(&iam.GenerateCredentialReportInput{})
+		if err != nil {
+			return nil, err
+		}
+		if aws.StringValue(generated.State) == iam.ReportStateTypeComplete {
+			break
+		}
+		if attempt == credentialReportAttempts {
+			return nil, fmt.Errorf("credential report not ready after %d attempts", attempt)
+		}
+		time.Sleep(2 * time.Second)
+	}
+
+	report, err := iamClient.GetCredentialReport(&iam.GetCredentialReportInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := csv.NewReader(strings.NewReader(string(report.Content))).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	rows := []map[string]string{}
+	for _, record := range records[1:] {
+		row := map[string]string{}
+		for i, column := range records[0] {
+			row[column] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// auditIAMUsers exibe o relatório de credenciais com sinalizações de chaves antigas ou sem uso,
+// console sem MFA e uso da conta root
+func auditIAMUsers(cmd *cobra.Command, iamClient *iam.IAM) {
+	keyAge, _ := cmd.Flags().GetInt("key-age")
+	unusedDays, _ := cmd.Flags().GetInt("unused-days")
+
+	rows, err := credentialReport(iamClient)
+	if err != nil {
+		fmt.Println("failed to get IAM credential report,", err)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"User", "Console Access", "MFA", "Password Age (days)", "Password Last Used", "Access Key 1", "Access Key 2", "Flags"}) // Define cabeçalhos da tabela
+
+	for _, row := range rows {
+		flags := []string{}
+		root := row["user"] == "<root_account>"
+
+		console := row["password_enabled"] == "true" || (root && row["password_enabled"] == "not_supported")
+		if console && row["mfa_active"] != "true" {
+			flags = append(flags, "console without MFA")
+		}
+
+		passwordAge := ""
+		if changed, ok := reportDate(row["password_last_changed"]); ok {
+			passwordAge = ageInDays(changed)
+		}
+
+		if root {
+			if used, ok := reportDate(row["password_last_used"]); ok && daysSince(used) <= unusedDays {
+				flags = append(flags, "root account used")
+			}
+		} else if console {
+			if used, ok := reportDate(row["password_last_used"]); !ok || daysSince(used) > unusedDays {
+				flags = append(flags, "unused password")
+			}
+		}
+
+		keys := []string{}
+		for _, key := range []string{"access_key_1", "access_key_2"} {
+			if row[key+"_active"] != "true" {
+				keys = append(keys, "")
+				continue
+			}
+
+			description := []string{}
+			if rotated, ok := reportDate(row[key+"_last_rotated"]); ok {
+				description = append(description, fmt.Sprintf("age %sd", ageInDays(rotated)))
+				if daysSince(rotated) > keyAge {
+					flags = append(flags, fmt.Sprintf("%s older than %d days", key, keyAge))
+				}
+			}
+
+			if used, ok := reportDate(row[key+"_last_used_date"]); ok {
+				description = append(description, fmt.Sprintf("used %s via %s in %s", used.Format("2006-01-02"),
+					row[key+"_last_used_service"], row[key+"_last_used_region"]))
+				if daysSince(used) > unusedDays {
+					flags = append(flags, key+" unused")
+				}
+			} else {
+				description = append(description, "never used")
+				flags = append(flags, key+" unused")
+			}
+
+			if root {
+				flags = append(flags, "root access key")
+			}
+			keys = append(keys, strings.Join(description, ", "))
+		}
+
+		table.Append([]string{
+			row["user"],
+			yesNo(console),
+			yesNo(row["mfa_active"] == "true"),
+			passwordAge,
+			row["password_last_used"],
+			keys[0],
+			keys[1],
+			strings.Join(flags, ", "),
+		})
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
+
+// reportDate converte uma data do relatório de credenciais; valores como "N/A" e
+// "no_information" retornam falso
+func reportDate(value string) (time.Time, bool) {
+	date, err := time.Parse(time.RFC3339, value)
+	return date, err == nil
+}
+
+// daysSince retorna quantos dias inteiros se passaram desde `t`
+func daysSince(t time.Time) int {
+	return int(time.Since(t).Hours() / 24)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"lookr/deps" // Importação de pacotes locais ou dependências
 	"os"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -21,13 +22,21 @@ var LambdaCmd = &cobra.Command{
 
 // init é chamado antes da execução do programa principal
 func init() {
+	LambdaCmd.Flags().BoolP("wide", "w", false, "Show package, code, layers, VPC, concurrency and event source columns")
 	rootCmd.AddCommand(LambdaCmd) // Adiciona o comando `lambda` como um subcomando do comando raiz
 }
 
 // queryLambda é a função que executa a lógica para consultar funções Lambda
 func queryLambda(cmd *cobra.Command, args []string) {
+	wide, _ := cmd.Flags().GetBool("wide")
+
+	header := []string{"Function Name", "Region", "Runtime", "Handler", "Memory (MB)", "Timeout (s)", "ARN"}
+	if wide {
+		header = append(header, "Package Type", "Architecture", "Code Size", "Last Modified", "Layers", "VPC", "Reserved Concurrency", "Provisioned Concurrency", "Env Vars", "Dead Letter", "Event Sources")
+	}
+
 	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
-	table.SetHeader([]string{"Function Name", "Region", "Runtime", "Handler", "Memory (MB)", "Timeout (s)", "ARN"}) // Define cabeçalhos da tabela
+	table.SetHeader(header) // Define cabeçalhos da tabela
 
 	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
 	for _, region := range AuthRegions { // Itera sobre cada região autorizada
@@ -42,8 +51,12 @@ func queryLambda(cmd *cobra.Command, args []string) {
 
 		lambdaClient := lambda.New(sess) // Cria um novo cliente Lambda com a sessão configurada
 
+		functions := []*lambda.FunctionConfiguration{}
 		input := &lambda.ListFunctionsInput{} // Cria um input para listar funções Lambda
-		result, err := lambdaClient.ListFunctions(input) // Lista as funções Lambda na região atual
+		err = lambdaClient.ListFunctionsPages(input, func(page *lambda.ListFunctionsOutput, lastPage bool) bool { // Lista as funções Lambda na região atual
+			functions = append(functions, page.Functions...)
+			return true
+		})
 		if err != nil {
 			fmt.Println("failed to list AWS Lambda functions,", err) // Imprime erro se a listagem de funções falhar
 			return
@@ -51,18 +64,134 @@ func queryLambda(cmd *cobra.Command, args []string) {
 
 		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
 
-		for _, function := range result.Functions { // Itera sobre cada função Lambda na lista de funções
+		for _, function := range functions { // Itera sobre cada função Lambda na lista de funções
 			row := []string{
-				*function.FunctionName,         // Nome da função Lambda
-				regionName,                     // Nome da região
-				*function.Runtime,              // Runtime da função Lambda (ex: nodejs, python)
-				*function.Handler,              // Handler da função Lambda
-				fmt.Sprintf("%d", *function.MemorySize), // Tamanho da memória em MB
-				fmt.Sprintf("%d", *function.Timeout),    // Timeout da função em segundos
-				*function.FunctionArn,          // ARN da função Lambda
+				aws.StringValue(function.FunctionName),                 // Nome da função Lambda
+				regionName,                                             // Nome da região
+				aws.StringValue(function.Runtime),                      // Runtime da função Lambda (vazio para imagens de contêiner)
+				aws.StringValue(function.Handler),                      // Handler da função Lambda (vazio para imagens de contêiner)
+				fmt.Sprintf("%d", aws.Int64Value(function.MemorySize)), // Tamanho da memória em MB
+				fmt.Sprintf("%d", aws.Int64Value(function.Timeout)),    // Timeout da função em segundos
+				aws.StringValue(function.FunctionArn),                  // ARN da função Lambda
+			}
+			if wide {
+				columns, err := lambdaWideColumns(lambdaClient, function)
+				if err != nil {
+					fmt.Println("failed to describe AWS Lambda function,", err)
+					return
+				}
+				row = append(row, columns...)
 			}
 			table.Append(row) // Adiciona a linha à tabela
 		}
 	}
 	table.Render() // Renderiza a tabela com os resultados
 }
+
+// lambdaWideColumns retorna as colunas extras do modo `--wide`, consultando concorrência
+// e mapeamentos de origem de eventos da função
+func lambdaWideColumns(lambdaClient *lambda.Lambda, function *lambda.FunctionConfiguration) ([]string, error) {
+	layers := []string{}
+	for _, layer := range function.Layers {
+		layers = append(layers, lambdaResourceName(aws.StringValue(layer.Arn)))
+	}
+
+	vpc := ""
+	if function.VpcConfig != nil && aws.StringValue(function.VpcConfig.VpcId) != "" {
+		vpc = fmt.Sprintf("%s (%d subnets)", aws.StringValue(function.VpcConfig.VpcId), len(function.VpcConfig.SubnetIds))
+	}
+
+	envVars := 0
+	if function.Environment != nil {
+		envVars = len(function.Environment.Variables)
+	}
+
+	deadLetter := ""
+	if function.DeadLetterConfig != nil {
+		deadLetter = aws.StringValue(function.DeadLetterConfig.TargetArn)
+	}
+
+	concurrency, err := lambdaClient.GetFunctionConcurrency(&lambda.GetFunctionConcurrencyInput{
+		FunctionName: function.FunctionName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	reserved := ""
+	if concurrency.ReservedConcurrentExecutions != nil {
+		reserved = fmt.Sprintf("%d", *concurrency.ReservedConcurrentExecutions)
+	}
+
+	provisioned := []string{}
+	err = lambdaClient.ListProvisionedConcurrencyConfigsPages(&lambda.ListProvisionedConcurrencyConfigsInput{
+		FunctionName: function.FunctionName,
+	}, func(page *lambda.ListProvisionedConcurrencyConfigsOutput, lastPage bool) bool {
+		for _, config := range page.ProvisionedConcurrencyConfigs {
+			provisioned = append(provisioned, fmt.Sprintf("%s: %d", lambdaResourceName(aws.StringValue(config.FunctionArn)),
+				aws.Int64Value(config.AllocatedProvisionedConcurrentExecutions)))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	eventSources := []string{}
+	err = lambdaClient.ListEventSourceMappingsPages(&lambda.ListEventSourceMappingsInput{
+		FunctionName: function.FunctionName,
+	}, func(page *lambda.ListEventSourceMappingsOutput, lastPage bool) bool {
+		for _, mapping := range page.EventSourceMappings {
+			eventSources = append(eventSources, fmt.Sprintf("%s (%s)", eventSourceName(aws.StringValue(mapping.EventSourceArn)), aws.StringValue(mapping.State)))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		aws.StringValue(function.PackageType),
+		strings.Join(aws.StringValueSlice(function.Architectures), ", "),
+		formatBytes(aws.Int64Value(function.CodeSize)),
+		aws.StringValue(function.LastModified),
+		strings.Join(layers, ", "),
+		vpc,
+		reserved,
+		strings.Join(provisioned, ", "),
+		fmt.Sprintf("%d", envVars),
+		deadLetter,
+		strings.Join(eventSources, ", "),
+	}, nil
+}
+
+// lambdaResourceName reduz um ARN às suas partes finais (ex: nome:versão de uma layer)
+func lambdaResourceName(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 7 {
+		return arn
+	}
+	return strings.Join(parts[6:], ":")
+}
+
+// eventSourceName reduz o ARN de uma origem de eventos a serviço e recurso (ex: sqs:minha-fila)
+func eventSourceName(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return arn
+	}
+	return parts[2] + ":" + parts[5]
+}
+
+// formatBytes converte um tamanho em bytes para a unidade mais legível
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
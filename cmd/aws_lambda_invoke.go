@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"lookr/deps" // Importação de pacotes locais ou dependências
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs" // Pacote para Amazon CloudWatch Logs
+	"github.com/aws/aws-sdk-go/service/lambda"         // Pacote para AWS Lambda
+	"github.com/spf13/cobra"                           // Pacote para criação de CLI usando Cobra
+)
+
+// lambdaInvokeCmd define o subcomando `lambda invoke` que executa uma função e exibe a resposta
+var lambdaInvokeCmd = &cobra.Command{
+	Use:   "invoke <function>",
+	Short: "Invoke a Lambda function and print the response and tail log", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   invokeLambda, // Função a ser executada quando o comando `lambda invoke` é chamado
+}
+
+// lambdaLogsCmd define o subcomando `lambda logs` que lê o grupo de logs da função
+var lambdaLogsCmd = &cobra.Command{
+	Use:   "logs <function>",
+	Short: "Print or follow the CloudWatch Logs of a Lambda function", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   lambdaLogs, // Função a ser executada quando o comando `lambda logs` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	lambdaInvokeCmd.Flags().String("payload", "", "JSON file sent as the event payload")
+	lambdaLogsCmd.Flags().BoolP("follow", "f", false, "Keep polling for new log events")
+	lambdaLogsCmd.Flags().String("filter", "", "CloudWatch Logs filter pattern")
+	lambdaLogsCmd.Flags().Duration("since", 10*time.Minute, "Show events newer than this duration")
+	lambdaLogsCmd.Flags().Duration("until", 0, "Show events older than this duration (0 means now)")
+
+	LambdaCmd.AddCommand(lambdaInvokeCmd, lambdaLogsCmd)
+}
+
+// resolveLambdaFunction procura a função nas regiões autorizadas, como o comando `lambda`,
+// e retorna a sessão da região onde ela existe
+func resolveLambdaFunction(name string) (*session.Session, *lambda.FunctionConfiguration, error) {
+	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
+	for _, region := range AuthRegions { // Itera sobre cada região autorizada
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região atual
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		configuration, err := lambda.New(sess).GetFunctionConfiguration(&lambda.GetFunctionConfigurationInput{
+			FunctionName: aws.String(name),
+		})
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == lambda.ErrCodeResourceNotFoundException {
+			continue // A função não existe nesta região
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return sess, configuration, nil
+	}
+	return nil, nil, fmt.Errorf("function %s not found in any region", name)
+}
+
+// invokeLambda invoca a função de forma síncrona e imprime status, resposta e o final do log
+func invokeLambda(cmd *cobra.Command, args []string) {
+	payloadPath, _ := cmd.Flags().GetString("payload")
+
+	payload := []byte("{}")
+	if payloadPath != "" {
+		content, err := os.ReadFile(payloadPath)
+		if err != nil {
+			fmt.Println("failed to read payload,", err)
+			return
+		}
+		payload = content
+	}
+
+	sess, configuration, err := resolveLambdaFunction(args[0])
+	if err != nil {
+		fmt.Println("failed to resolve AWS Lambda function,", err)
+		return
+	}
+
+	result, err := lambda.New(sess).Invoke(&lambda.InvokeInput{
+		FunctionName: configuration.FunctionArn,
+		Payload:      payload,
+		LogType:      aws.String(lambda.LogTypeTail), // Retorna os últimos 4 KB do log da execução
+	})
+	if err != nil {
+		fmt.Println("failed to invoke AWS Lambda function,", err)
+		return
+	}
+
+	fmt.Println("Status:", aws.Int64Value(result.StatusCode))
+	fmt.Println("Executed Version:", aws.StringValue(result.ExecutedVersion))
+	if result.FunctionError != nil {
+		fmt.Println("Function Error:", *result.FunctionError)
+	}
+	fmt.Println("Response:")
+	fmt.Println(string(result.Payload))
+
+	if result.LogResult != nil {
+		logs, err := base64.StdEncoding.DecodeString(*result.LogResult)
+		if err != nil {
+			fmt.Println("failed to decode log result,", err)
+			return
+		}
+		fmt.Println("Log:")
+		fmt.Println(string(logs))
+	}
+}
+
+// lambdaLogs filtra os eventos do grupo de logs da função e, com `--follow`, continua
+// consultando novos eventos até o comando ser interrompido
+func lambdaLogs(cmd *cobra.Command, args []string) {
+	follow, _ := cmd.Flags().GetBool("follow")
+	filter, _ := cmd.Flags().GetString("filter")
+	since, _ := cmd.Flags().GetDuration("since")
+	until, _ := cmd.Flags().GetDuration("until")
+
+	if follow && until > 0 {
+		fmt.Println("--until cannot be combined with --follow") // A janela terminaria no passado e nada novo seria exibido
+		return
+	}
+
+	sess, configuration, err := resolveLambdaFunction(args[0])
+	if err != nil {
+		fmt.Println("failed to resolve AWS Lambda function,", err)
+		return
+	}
+
+	logsClient := cloudwatchlogs.New(sess) // Cria um novo cliente CloudWatch Logs na região da função
+	logGroup := "/aws/lambda/" + aws.StringValue(configuration.FunctionName)
+
+	start := time.Now().Add(-since)
+	seen := map[string]int64{} // Eventos já impressos e seus timestamps, pois consultas sucessivas se sobrepõem
+	for {
+		input := &cloudwatchlogs.FilterLogEventsInput{
+			LogGroupName: aws.String(logGroup),
+			StartTime:    aws.Int64(start.UnixMilli()),
+		}
+		if filter != "" {
+			input.FilterPattern = aws.String(filter)
+		}
+		if until > 0 {
+			input.EndTime = aws.Int64(time.Now().Add(-until).UnixMilli())
+		}
+
+		err := logsClient.FilterLogEventsPages(input, func(page *cloudwatchlogs.FilterLogEventsOutput, lastPage bool) bool {
+			for _, event := range page.Events {
+				if _, found := seen[aws.StringValue(event.EventId)]; found {
+					continue
+				}
+				seen[aws.StringValue(event.EventId)] = aws.Int64Value(event.Timestamp)
+
+				timestamp := time.UnixMilli(aws.Int64Value(event.Timestamp))
+				if timestamp.After(start) {
+					start = timestamp // A próxima consulta começa no evento mais recente
+				}
+				fmt.Printf("%s %s %s\n", timestamp.Format(time.RFC3339), aws.StringValue(event.LogStreamName),
+					strings.TrimRight(aws.StringValue(event.Message), "\n"))
+			}
+			return true
+		})
+		if err != nil {
+			fmt.Println("failed to filter log events,", err)
+			return
+		}
+
+		if !follow {
+			return
+		}
+		for id, timestamp := range seen { // Eventos anteriores ao início da próxima consulta não voltam mais
+			if timestamp < start.UnixMilli() {
+				delete(seen, id)
+			}
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
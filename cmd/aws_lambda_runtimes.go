@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"lookr/deps" // Importação de pacotes locais ou dependências
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda" // Pacote para AWS Lambda
+	"github.com/olekukonko/tablewriter"        // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                   // Pacote para criação de CLI usando Cobra
+)
+
+// lambdaRuntimesCmd define o subcomando `lambda runtimes` que compara os runtimes em uso
+// com o calendário de descontinuação da AWS
+var lambdaRuntimesCmd = &cobra.Command{
+	Use:   "runtimes",
+	Short: "Group Lambda functions by runtime and flag deprecated runtimes", // Descrição breve do comando
+	Run:   queryLambdaRuntimes, // Função a ser executada quando o comando `lambda runtimes` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	lambdaRuntimesCmd.Flags().String("calendar", "", "JSON file overriding the embedded deprecation calendar")
+	lambdaRuntimesCmd.Flags().Int("within", 180, "Flag runtimes deprecated within this many days")
+	LambdaCmd.AddCommand(lambdaRuntimesCmd) // Adiciona o comando `runtimes` como um subcomando de `lambda`
+}
+
+// lambdaRuntimeUsage acumula as funções que usam um runtime
+type lambdaRuntimeUsage struct {
+	functions []string
+	regions   map[string]bool
+}
+
+// queryLambdaRuntimes agrupa as funções de todas as regiões por runtime e destaca as que estão em risco
+func queryLambdaRuntimes(cmd *cobra.Command, args []string) {
+	calendarPath, _ := cmd.Flags().GetString("calendar")
+	within, _ := cmd.Flags().GetInt("within")
+
+	calendar, err := deps.LambdaRuntimeCalendar(calendarPath)
+	if err != nil {
+		fmt.Println("failed to load runtime calendar,", err)
+		return
+	}
+
+	usage := map[string]*lambdaRuntimeUsage{}
+
+	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
+	for _, region := range AuthRegions { // Itera sobre cada região autorizada
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região atual
+		})
+
+		if err != nil {
+			fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+			return
+		}
+
+		lambdaClient := lambda.New(sess) // Cria um novo cliente Lambda com a sessão configurada
+		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
+
+		err = lambdaClient.ListFunctionsPages(&lambda.ListFunctionsInput{}, func(page *lambda.ListFunctionsOutput, lastPage bool) bool {
+			for _, function := range page.Functions {
+				runtime := aws.StringValue(function.Runtime)
+				if runtime == "" {
+					runtime = "container image" // Imagens de contêiner não têm runtime gerenciado
+				}
+				if usage[runtime] == nil {
+					usage[runtime] = &lambdaRuntimeUsage{regions: map[string]bool{}}
+				}
+				usage[runtime].functions = append(usage[runtime].functions, fmt.Sprintf("%s (%s)", aws.StringValue(function.FunctionName), regionName))
+				usage[runtime].regions[regionName] = true
+			}
+			return true
+		})
+		if err != nil {
+			fmt.Println("failed to list AWS Lambda functions,", err) // Imprime erro se a listagem de funções falhar
+			return
+		}
+	}
+
+	runtimes := []string{}
+	for runtime := range usage {
+		runtimes = append(runtimes, runtime)
+	}
+	sort.Strings(runtimes)
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Runtime", "Status", "Deprecation", "Block Create", "Block Update", "Functions", "Regions"}) // Define cabeçalhos da tabela
+
+	atRisk := tablewriter.NewWriter(os.Stdout)
+	atRisk.SetHeader([]string{"Function", "Runtime", "Status", "Deprecation"})
+	atRiskCount := 0
+
+	for _, runtime := range runtimes {
+		dates := calendar[runtime]
+		status := runtimeStatus(dates, time.Now(), within)
+
+		regions := []string{}
+		for region := range usage[runtime].regions {
+			regions = append(regions, region)
+		}
+		sort.Strings(regions)
+
+		row := []string{
+			runtime,
+			status,
+			dates.Deprecation,
+			dates.BlockCreate,
+			dates.BlockUpdate,
+			fmt.Sprintf("%d", len(usage[runtime].functions)),
+			strings.Join(regions, ", "),
+		}
+		table.Rich(row, runtimeColors(status, len(row)))
+
+		if status == "supported" || status == "unknown" {
+			continue
+		}
+		for _, function := range usage[runtime].functions { // Lista cada função em risco
+			functionRow := []string{function, runtime, status, dates.Deprecation}
+			atRisk.Rich(functionRow, runtimeColors(status, len(functionRow)))
+			atRiskCount++
+		}
+	}
+	table.Render() // Renderiza a tabela com os resultados
+
+	if atRiskCount > 0 {
+		fmt.Println()
+		atRisk.Render()
+	}
+}
+
+// runtimeStatus classifica o runtime de acordo com a fase mais avançada do calendário já atingida
+func runtimeStatus(dates deps.LambdaRuntime, now time.Time, within int) string {
+	reached := func(date string) bool {
+		parsed, err := time.Parse("2006-01-02", date)
+		return err == nil && !now.Before(parsed)
+	}
+
+	switch {
+	case dates.Runtime == "":
+		return "unknown" // Runtime fora do calendário
+	case reached(dates.BlockUpdate):
+		return "update blocked"
+	case reached(dates.BlockCreate):
+		return "create blocked"
+	case reached(dates.Deprecation):
+		return "deprecated"
+	}
+
+	if deprecation, err := time.Parse("2006-01-02", dates.Deprecation); err == nil && deprecation.Sub(now) <= time.Duration(within)*24*time.Hour {
+		return "deprecated soon"
+	}
+	return "supported"
+}
+
+// runtimeColors define a cor da linha: vermelho para runtimes descontinuados e amarelo para os próximos
+func runtimeColors(status string, columns int) []tablewriter.Colors {
+	var color tablewriter.Colors
+	switch status {
+	case "update blocked", "create blocked", "deprecated":
+		color = tablewriter.Colors{tablewriter.FgRedColor}
+	case "deprecated soon":
+		color = tablewriter.Colors{tablewriter.FgYellowColor}
+	}
+
+	colors := make([]tablewriter.Colors, columns)
+	for i := range colors {
+		colors[i] = color
+	}
+	return colors
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"lookr/deps" // Importação de pacotes locais ou dependências
 	"os"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/rds" // Pacote para AWS RDS
 	"github.com/olekukonko/tablewriter" // Pacote para formatação de tabelas
@@ -19,15 +22,34 @@ var RdsCmd = &cobra.Command{
 	Run:   queryRDS, // Função a ser executada quando o comando `rds` é chamado
 }
 
+// rdsDescribeCmd define o subcomando `rds describe` que detalha uma instância
+var rdsDescribeCmd = &cobra.Command{
+	Use:   "describe <db-instance-id>",
+	Short: "Show backup, maintenance and security details of an RDS instance", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   describeRDS, // Função a ser executada quando o comando `rds describe` é chamado
+}
+
 // init é chamado antes da execução do programa principal
 func init() {
+	RdsCmd.Flags().BoolP("wide", "w", false, "Show backup, maintenance and security columns")
+	RdsCmd.AddCommand(rdsDescribeCmd)
 	rootCmd.AddCommand(RdsCmd) // Adiciona o comando `rds` como um subcomando do comando raiz
 }
 
 // queryRDS é a função que executa a lógica para consultar instâncias RDS
 func queryRDS(cmd *cobra.Command, args []string) {
+	wide, _ := cmd.Flags().GetBool("wide")
+
+	header := []string{"DB Name", "Region", "AZ", "Status", "Instance Type", "Engine", "Version", "Port", "Storage Type", "Storage Size", "Multi-AZ", "Replica", "ARN"}
+	if wide {
+		for _, field := range rdsAuditFields(&rds.DBInstance{}, nil) { // Acrescenta as colunas de auditoria
+			header = append(header, field[0])
+		}
+	}
+
 	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
-	table.SetHeader([]string{"DB Name", "Region", "AZ", "Status", "Instance Type", "Engine", "Version", "Port", "Storage Type", "Storage Size", "Multi-AZ", "Replica", "ARN"}) // Define cabeçalhos da tabela
+	table.SetHeader(header) // Define cabeçalhos da tabela
 
 	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
 	for _, region := range AuthRegions { // Itera sobre cada região autorizada
@@ -49,6 +71,15 @@ func queryRDS(cmd *cobra.Command, args []string) {
 			return
 		}
 
+		pending := map[string][]string{}
+		if wide && len(result.DBInstances) > 0 {
+			pending, err = pendingMaintenance(rdsClient) // Obtém as ações de manutenção pendentes da região
+			if err != nil {
+				fmt.Println("failed to describe pending maintenance actions,", err)
+				return
+			}
+		}
+
 		for _, dbInstance := range result.DBInstances { // Itera sobre cada instância RDS na lista de instâncias
 			hasReadReplica := "No"
 			if len(dbInstance.ReadReplicaDBInstanceIdentifiers) > 0 {
@@ -77,8 +108,125 @@ func queryRDS(cmd *cobra.Command, args []string) {
 				hasReadReplica,                   // Indica se tem réplica de leitura
 				*dbInstance.DBInstanceArn,        // ARN da instância RDS
 			}
+			if wide {
+				for _, field := range rdsAuditFields(dbInstance, pending[*dbInstance.DBInstanceArn]) {
+					row = append(row, field[1])
+				}
+			}
 			table.Append(row) // Adiciona a linha à tabela
 		}
 	}
 	table.Render() // Renderiza a tabela com os resultados
 }
+
+// describeRDS procura a instância informada em todas as regiões e exibe seus detalhes
+func describeRDS(cmd *cobra.Command, args []string) {
+	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
+	for _, region := range AuthRegions { // Itera sobre cada região autorizada
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região atual
+		})
+
+		if err != nil {
+			fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+			return
+		}
+
+		rdsClient := rds.New(sess) // Cria um novo cliente RDS com a sessão configurada
+
+		input := &rds.DescribeDBInstancesInput{
+			DBInstanceIdentifier: aws.String(args[0]), // Filtra pela instância informada
+		}
+		result, err := rdsClient.DescribeDBInstances(input)
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == rds.ErrCodeDBInstanceNotFoundFault {
+			continue // A instância não existe nesta região
+		}
+		if err != nil {
+			fmt.Println("failed to describe db instances,", err) // Imprime erro se a descrição de instâncias falhar
+			return
+		}
+
+		pending, err := pendingMaintenance(rdsClient)
+		if err != nil {
+			fmt.Println("failed to describe pending maintenance actions,", err)
+			return
+		}
+
+		for _, dbInstance := range result.DBInstances {
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Field", "Value"})
+			table.SetAutoWrapText(false)
+
+			table.Append([]string{"DB Name", aws.StringValue(dbInstance.DBInstanceIdentifier)})
+			table.Append([]string{"Region", deps.GetRegionName(region)})
+			table.Append([]string{"Status", aws.StringValue(dbInstance.DBInstanceStatus)})
+			table.Append([]string{"Engine", aws.StringValue(dbInstance.Engine) + " " + aws.StringValue(dbInstance.EngineVersion)})
+			table.Append([]string{"Instance Type", aws.StringValue(dbInstance.DBInstanceClass)})
+			for _, field := range rdsAuditFields(dbInstance, pending[aws.StringValue(dbInstance.DBInstanceArn)]) {
+				table.Append([]string{field[0], field[1]})
+			}
+			table.Append([]string{"ARN", aws.StringValue(dbInstance.DBInstanceArn)})
+			table.Render()
+		}
+		return
+	}
+	fmt.Println("db instance not found in any region,", args[0])
+}
+
+// rdsAuditFields retorna os pares (coluna, valor) usados nas auditorias de uma instância RDS,
+// compartilhados pelo modo `--wide` e pelo subcomando `rds describe`
+func rdsAuditFields(dbInstance *rds.DBInstance, pending []string) [][2]string {
+	latestRestorable := ""
+	if dbInstance.LatestRestorableTime != nil {
+		latestRestorable = dbInstance.LatestRestorableTime.Format(time.RFC3339)
+	}
+
+	parameterGroups := []string{}
+	for _, group := range dbInstance.DBParameterGroups { // Nome do parameter group e status de sincronização
+		parameterGroups = append(parameterGroups, fmt.Sprintf("%s (%s)", aws.StringValue(group.DBParameterGroupName), aws.StringValue(group.ParameterApplyStatus)))
+	}
+
+	encryption := yesNo(aws.BoolValue(dbInstance.StorageEncrypted))
+	if dbInstance.KmsKeyId != nil {
+		encryption = *dbInstance.KmsKeyId
+	}
+
+	caExpiry := aws.StringValue(dbInstance.CACertificateIdentifier)
+	if dbInstance.CertificateDetails != nil && dbInstance.CertificateDetails.ValidTill != nil {
+		caExpiry = fmt.Sprintf("%s (%s)", caExpiry, dbInstance.CertificateDetails.ValidTill.Format("2006-01-02"))
+	}
+
+	return [][2]string{
+		{"Backup Retention (days)", fmt.Sprintf("%d", aws.Int64Value(dbInstance.BackupRetentionPeriod))},
+		{"Latest Restorable", latestRestorable},
+		{"Pending Maintenance", strings.Join(pending, ", ")},
+		{"Parameter Group", strings.Join(parameterGroups, ", ")},
+		{"Encryption", encryption},
+		{"Public", yesNo(aws.BoolValue(dbInstance.PubliclyAccessible))},
+		{"Deletion Protection", yesNo(aws.BoolValue(dbInstance.DeletionProtection))},
+		{"IAM Auth", yesNo(aws.BoolValue(dbInstance.IAMDatabaseAuthenticationEnabled))},
+		{"Performance Insights", yesNo(aws.BoolValue(dbInstance.PerformanceInsightsEnabled))},
+		{"CA Certificate", caExpiry},
+	}
+}
+
+// pendingMaintenance retorna as ações de manutenção pendentes da região, indexadas pelo ARN do recurso
+func pendingMaintenance(rdsClient *rds.RDS) (map[string][]string, error) {
+	pending := map[string][]string{}
+
+	input := &rds.DescribePendingMaintenanceActionsInput{}
+	err := rdsClient.DescribePendingMaintenanceActionsPages(input, func(page *rds.DescribePendingMaintenanceActionsOutput, lastPage bool) bool {
+		for _, resource := range page.PendingMaintenanceActions {
+			arn := aws.StringValue(resource.ResourceIdentifier)
+			for _, action := range resource.PendingMaintenanceActionDetails {
+				description := aws.StringValue(action.Action)
+				if action.CurrentApplyDate != nil {
+					description += " @ " + action.CurrentApplyDate.Format("2006-01-02")
+				}
+				pending[arn] = append(pending[arn], description)
+			}
+		}
+		return true
+	})
+	return pending, err
+}
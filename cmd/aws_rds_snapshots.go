@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"lookr/deps" // Importação de pacotes locais ou dependências
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rds" // Pacote para AWS RDS
+	"github.com/olekukonko/tablewriter"     // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                // Pacote para criação de CLI usando Cobra
+)
+
+// rdsSnapshotsCmd define o subcomando `rds snapshots` que audita snapshots de instâncias e clusters
+var rdsSnapshotsCmd = &cobra.Command{
+	Use:   "snapshots",
+	Short: "Query RDS and Aurora snapshots and flag old or publicly shared ones", // Descrição breve do comando
+	Run:   queryRDSSnapshots, // Função a ser executada quando o comando `rds snapshots` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	rdsSnapshotsCmd.Flags().Int("retention", 30, "Flag manual snapshots older than this many days")
+	RdsCmd.AddCommand(rdsSnapshotsCmd) // Adiciona o comando `snapshots` como um subcomando de `rds`
+}
+
+// rdsSnapshot reúne os campos comuns de snapshots de instâncias e de clusters
+type rdsSnapshot struct {
+	identifier   string
+	kind         string
+	source       string
+	snapshotType string
+	size         int64
+	created      time.Time
+	encrypted    bool
+	sourceRegion string
+	sharedWith   []string
+}
+
+// queryRDSSnapshots é a função que executa a lógica para consultar snapshots RDS e Aurora
+func queryRDSSnapshots(cmd *cobra.Command, args []string) {
+	retention, _ := cmd.Flags().GetInt("retention")
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Snapshot ID", "Kind", "Region", "Source", "Type", "Size (GB)", "Age (days)", "Encryption", "Source Region", "Shared With", "Flags"}) // Define cabeçalhos da tabela
+
+	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
+	for _, region := range AuthRegions { // Itera sobre cada região autorizada
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região atual
+		})
+
+		if err != nil {
+			fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+			return
+		}
+
+		rdsClient := rds.New(sess) // Cria um novo cliente RDS com a sessão configurada
+
+		snapshots, err := describeDBSnapshots(rdsClient, region)
+		if err != nil {
+			fmt.Println("failed to describe db snapshots,", err) // Imprime erro se a descrição falhar
+			return
+		}
+
+		clusterSnapshots, err := describeDBClusterSnapshots(rdsClient, region)
+		if err != nil {
+			fmt.Println("failed to describe db cluster snapshots,", err) // Imprime erro se a descrição falhar
+			return
+		}
+
+		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
+
+		for _, snapshot := range append(snapshots, clusterSnapshots...) { // Itera sobre cada snapshot listado
+			flags := []string{}
+			if snapshot.snapshotType == "manual" && time.Since(snapshot.created) > time.Duration(retention)*24*time.Hour {
+				flags = append(flags, "retention exceeded")
+			}
+			for _, account := range snapshot.sharedWith {
+				if account == "all" { // "all" indica que o snapshot pode ser restaurado por qualquer conta
+					flags = append(flags, "PUBLIC")
+				}
+			}
+
+			row := []string{
+				snapshot.identifier,
+				snapshot.kind,
+				regionName,
+				snapshot.source,
+				snapshot.snapshotType,
+				fmt.Sprintf("%d", snapshot.size),
+				ageInDays(snapshot.created),
+				yesNo(snapshot.encrypted),
+				snapshot.sourceRegion,
+				strings.Join(snapshot.sharedWith, ", "),
+				strings.Join(flags, ", "),
+			}
+			table.Append(row) // Adiciona a linha à tabela
+		}
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
+
+// describeDBSnapshots lista os snapshots de instâncias da região com as contas com que foram compartilhados
+func describeDBSnapshots(rdsClient *rds.RDS, region string) ([]rdsSnapshot, error) {
+	snapshots := []rdsSnapshot{}
+	err := rdsClient.DescribeDBSnapshotsPages(&rds.DescribeDBSnapshotsInput{}, func(page *rds.DescribeDBSnapshotsOutput, lastPage bool) bool {
+		for _, snapshot := range page.DBSnapshots {
+			sourceRegion := aws.StringValue(snapshot.SourceRegion)
+			if sourceRegion == region {
+				sourceRegion = ""
+			}
+			snapshots = append(snapshots, rdsSnapshot{
+				identifier:   aws.StringValue(snapshot.DBSnapshotIdentifier),
+				kind:         "Instance",
+				source:       aws.StringValue(snapshot.DBInstanceIdentifier),
+				snapshotType: aws.StringValue(snapshot.SnapshotType),
+				size:         aws.Int64Value(snapshot.AllocatedStorage),
+				created:      aws.TimeValue(snapshot.SnapshotCreateTime),
+				encrypted:    aws.BoolValue(snapshot.Encrypted),
+				sourceRegion: sourceRegion,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range snapshots {
+		if snapshots[i].snapshotType != "manual" {
+			continue // Apenas snapshots manuais podem ser compartilhados
+		}
+		attributes, err := rdsClient.DescribeDBSnapshotAttributes(&rds.DescribeDBSnapshotAttributesInput{
+			DBSnapshotIdentifier: aws.String(snapshots[i].identifier),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, attribute := range attributes.DBSnapshotAttributesResult.DBSnapshotAttributes {
+			if aws.StringValue(attribute.AttributeName) == "restore" {
+				snapshots[i].sharedWith = aws.StringValueSlice(attribute.AttributeValues)
+			}
+		}
+	}
+	return snapshots, nil
+}
+
+// describeDBClusterSnapshots lista os snapshots de clusters da região com as contas com que foram compartilhados
+func describeDBClusterSnapshots(rdsClient *rds.RDS, region string) ([]rdsSnapshot, error) {
+	snapshots := []rdsSnapshot{}
+	err := rdsClient.DescribeDBClusterSnapshotsPages(&rds.DescribeDBClusterSnapshotsInput{}, func(page *rds.DescribeDBClusterSnapshotsOutput, lastPage bool) bool {
+		for _, snapshot := range page.DBClusterSnapshots {
+			sourceRegion := ""
+			if source := aws.StringValue(snapshot.SourceDBClusterSnapshotArn); source != "" {
+				if arnRegion := regionFromARN(source); arnRegion != region { // Snapshot copiado de outra região
+					sourceRegion = arnRegion
+				}
+			}
+			snapshots = append(snapshots, rdsSnapshot{
+				identifier:   aws.StringValue(snapshot.DBClusterSnapshotIdentifier),
+				kind:         "Cluster",
+				source:       aws.StringValue(snapshot.DBClusterIdentifier),
+				snapshotType: aws.StringValue(snapshot.SnapshotType),
+				size:         aws.Int64Value(snapshot.AllocatedStorage),
+				created:      aws.TimeValue(snapshot.SnapshotCreateTime),
+				encrypted:    aws.BoolValue(snapshot.StorageEncrypted),
+				sourceRegion: sourceRegion,
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range snapshots {
+		if snapshots[i].snapshotType != "manual" {
+			continue // Apenas snapshots manuais podem ser compartilhados
+		}
+		attributes, err := rdsClient.DescribeDBClusterSnapshotAttributes(&rds.DescribeDBClusterSnapshotAttributesInput{
+			DBClusterSnapshotIdentifier: aws.String(snapshots[i].identifier),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, attribute := range attributes.DBClusterSnapshotAttributesResult.DBClusterSnapshotAttributes {
+			if aws.StringValue(attribute.AttributeName) == "restore" {
+				snapshots[i].sharedWith = aws.StringValueSlice(attribute.AttributeValues)
+			}
+		}
+	}
+	return snapshots, nil
+}
+
+// regionFromARN extrai a região de um ARN (arn:partition:service:region:account:resource)
+func regionFromARN(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 4 {
+		return ""
+	}
+	return parts[3]
+}
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"fmt"
+	"lookr/deps" // Importação de pacotes locais ou dependências
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/rds" // Pacote para AWS RDS
+	"github.com/olekukonko/tablewriter"     // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                // Pacote para criação de CLI usando Cobra
+)
+
+// rdsUpgradesCmd define o subcomando `rds upgrades` que aponta versões de engine a atualizar
+var rdsUpgradesCmd = &cobra.Command{
+	Use:   "upgrades",
+	Short: "Report engine upgrade targets and deprecated versions for RDS and Aurora", // Descrição breve do comando
+	Run:   queryRDSUpgrades, // Função a ser executada quando o comando `rds upgrades` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	RdsCmd.AddCommand(rdsUpgradesCmd) // Adiciona o comando `upgrades` como um subcomando de `rds`
+}
+
+// rdsDatabase representa uma instância ou cluster cuja versão de engine será avaliada
+type rdsDatabase struct {
+	identifier string
+	kind       string
+	engine     string
+	version    string
+}
+
+// queryRDSUpgrades é a função que compara as versões de engine em uso com as disponíveis na AWS
+func queryRDSUpgrades(cmd *cobra.Command, args []string) {
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Identifier", "Type", "Region", "Engine", "Current Version", "Latest Minor", "Next Major", "Lifecycle"}) // Define cabeçalhos da tabela
+
+	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
+	for _, region := range AuthRegions { // Itera sobre cada região autorizada
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região atual
+		})
+
+		if err != nil {
+			fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+			return
+		}
+
+		rdsClient := rds.New(sess) // Cria um novo cliente RDS com a sessão configurada
+
+		databases := []rdsDatabase{}
+
+		err = rdsClient.DescribeDBInstancesPages(&rds.DescribeDBInstancesInput{}, func(page *rds.DescribeDBInstancesOutput, lastPage bool) bool {
+			for _, dbInstance := range page.DBInstances {
+				if dbInstance.DBClusterIdentifier != nil {
+					continue // Membros de cluster seguem a versão do cluster
+				}
+				databases = append(databases, rdsDatabase{
+					identifier: aws.StringValue(dbInstance.DBInstanceIdentifier),
+					kind:       "Instance",
+					engine:     aws.StringValue(dbInstance.Engine),
+					version:    aws.StringValue(dbInstance.EngineVersion),
+				})
+			}
+			return true
+		})
+		if err != nil {
+			fmt.Println("failed to describe db instances,", err) // Imprime erro se a descrição de instâncias falhar
+			return
+		}
+
+		err = rdsClient.DescribeDBClustersPages(&rds.DescribeDBClustersInput{}, func(page *rds.DescribeDBClustersOutput, lastPage bool) bool {
+			for _, cluster := range page.DBClusters {
+				databases = append(databases, rdsDatabase{
+					identifier: aws.StringValue(cluster.DBClusterIdentifier),
+					kind:       "Cluster",
+					engine:     aws.StringValue(cluster.Engine),
+					version:    aws.StringValue(cluster.EngineVersion),
+				})
+			}
+			return true
+		})
+		if err != nil {
+			fmt.Println("failed to describe db clusters,", err) // Imprime erro se a descrição de clusters falhar
+			return
+		}
+
+		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
+		engineVersions := map[string]map[string]*rds.DBEngineVersion{} // Cache de versões por engine
+
+		for _, database := range databases {
+			versions, found := engineVersions[database.engine]
+			if !found {
+				versions, err = describeEngineVersions(rdsClient, database.engine)
+				if err != nil {
+					fmt.Println("failed to describe db engine versions,", err)
+					return
+				}
+				engineVersions[database.engine] = versions
+			}
+
+			latestMinor, nextMajor, lifecycle := engineUpgradeAdvice(versions, database.version)
+
+			row := []string{
+				database.identifier,
+				database.kind,
+				regionName,
+				database.engine,
+				database.version,
+				latestMinor,
+				nextMajor,
+				lifecycle,
+			}
+			table.Append(row) // Adiciona a linha à tabela
+		}
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
+
+// describeEngineVersions retorna todas as versões da engine, inclusive as descontinuadas, indexadas pela versão
+func describeEngineVersions(rdsClient *rds.RDS, engine string) (map[string]*rds.DBEngineVersion, error) {
+	versions := map[string]*rds.DBEngineVersion{}
+
+	input := &rds.DescribeDBEngineVersionsInput{
+		Engine:     aws.String(engine),
+		IncludeAll: aws.Bool(true), // Inclui versões descontinuadas
+	}
+	err := rdsClient.DescribeDBEngineVersionsPages(input, func(page *rds.DescribeDBEngineVersionsOutput, lastPage bool) bool {
+		for _, version := range page.DBEngineVersions {
+			versions[aws.StringValue(version.EngineVersion)] = version
+		}
+		return true
+	})
+	return versions, err
+}
+
+// engineUpgradeAdvice calcula a última versão minor, a próxima versão major e o ciclo de vida
+// da versão atual. A versão é considerada em "extended support" quando todas as versões da
+// mesma major já estão descontinuadas.
+func engineUpgradeAdvice(versions map[string]*rds.DBEngineVersion, current string) (string, string, string) {
+	currentVersion, found := versions[current]
+	if !found {
+		return "", "", "unknown"
+	}
+
+	latestMinor := current
+	nextMajor := ""
+	nextMajorFamily := ""
+	for _, target := range currentVersion.ValidUpgradeTarget {
+		targetVersion := aws.StringValue(target.EngineVersion)
+		if !aws.BoolValue(target.IsMajorVersionUpgrade) {
+			if compareVersions(targetVersion, latestMinor) > 0 {
+				latestMinor = targetVersion
+			}
+			continue
+		}
+
+		family := targetVersion
+		if details, ok := versions[targetVersion]; ok && details.MajorEngineVersion != nil {
+			family = *details.MajorEngineVersion
+		}
+
+		// Mantém a menor major disponível e, dentro dela, a versão mais recente
+		switch {
+		case nextMajorFamily == "" || compareVersions(family, nextMajorFamily) < 0:
+			nextMajorFamily, nextMajor = family, targetVersion
+		case family == nextMajorFamily && compareVersions(targetVersion, nextMajor) > 0:
+			nextMajor = targetVersion
+		}
+	}
+
+	lifecycle := "standard"
+	if aws.StringValue(currentVersion.Status) == "deprecated" {
+		lifecycle = "deprecated"
+
+		majorAvailable := false
+		for _, version := range versions {
+			if aws.StringValue(version.MajorEngineVersion) == aws.StringValue(currentVersion.MajorEngineVersion) &&
+				aws.StringValue(version.Status) != "deprecated" {
+				majorAvailable = true
+				break
+			}
+		}
+		if !majorAvailable {
+			lifecycle = "extended support"
+		}
+	}
+	return latestMinor, nextMajor, lifecycle
+}
+
+// compareVersions compara duas versões de engine parte a parte, numericamente quando possível.
+// Retorna -1, 0 ou 1 como strings.Compare.
+func compareVersions(a, b string) int {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		numA, errA := strconv.Atoi(partsA[i])
+		numB, errB := strconv.Atoi(partsB[i])
+		if errA != nil || errB != nil {
+			if c := strings.Compare(partsA[i], partsB[i]); c != 0 {
+				return c
+			}
+			continue
+		}
+		if numA != numB {
+			if numA < numB {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(partsA) < len(partsB):
+		return -1
+	case len(partsA) > len(partsB):
+		return 1
+	}
+	return 0
+}
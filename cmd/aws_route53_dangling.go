@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"lookr/deps" // Importação de pacotes locais ou dependências
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudfront" // Pacote para AWS CloudFront
+	"github.com/aws/aws-sdk-go/service/ec2"        // Pacote para EC2 (Elastic Compute Cloud)
+	"github.com/aws/aws-sdk-go/service/elb"        // Pacote para ELB clássico
+	"github.com/aws/aws-sdk-go/service/elbv2"      // Pacote para ELBv2 (Elastic Load Balancing)
+	"github.com/aws/aws-sdk-go/service/route53"    // Pacote para AWS Route 53
+	"github.com/aws/aws-sdk-go/service/s3"         // Pacote para AWS S3
+	"github.com/olekukonko/tablewriter"            // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                       // Pacote para criação de CLI usando Cobra
+)
+
+// route53DanglingCmd define o subcomando `route53 dangling` que procura registros apontando para recursos inexistentes
+var route53DanglingCmd = &cobra.Command{
+	Use:   "dangling",
+	Short: "Find records pointing to AWS resources that no longer exist in the account", // Descrição breve do comando
+	Run:   findDanglingRecords, // Função a ser executada quando o comando `route53 dangling` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	route53DanglingCmd.Flags().Bool("ips", false, "Also report A/AAAA records whose IP is not an Elastic IP of the account")
+	Route53Cmd.AddCommand(route53DanglingCmd) // Adiciona o comando `dangling` como um subcomando de `route53`
+}
+
+// s3EndpointPattern identifica endpoints S3 REST e website, com o bucket opcional no início. Regiões
+// mais novas usam ponto antes da região (s3-website.eu-central-1) e as antigas, hífen (s3-website-us-east-1).
+var s3EndpointPattern = regexp.MustCompile(`^(?:(.+)\.)?s3(?:-website)?(?:\.dualstack)?(?:[.-][a-z0-9-]+)?\.amazonaws\.com$`)
+
+// accountTargets reúne os destinos que existem na conta, normalizados com `normalizeDNSName`
+type accountTargets struct {
+	loadBalancers map[string]bool // DNS names de ELB clássicos e ELBv2
+	distributions map[string]bool // Domínios *.cloudfront.net
+	buckets       map[string]bool // Nomes de buckets S3
+	addresses     map[string]bool // Elastic IPs
+}
+
+// collectAccountTargets lista load balancers e Elastic IPs de todas as regiões autorizadas,
+// além das distribuições CloudFront e buckets S3 da conta
+func collectAccountTargets() (*accountTargets, error) {
+	targets := &accountTargets{
+		loadBalancers: map[string]bool{},
+		distributions: map[string]bool{},
+		buckets:       map[string]bool{},
+		addresses:     map[string]bool{},
+	}
+
+	for _, region := range deps.AuthRegions() { // Itera sobre cada região autorizada
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região atual
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		loadBalancers, err := listLoadBalancers(elbv2.New(sess))
+		if err != nil {
+			return nil, err
+		}
+		for _, lb := range loadBalancers {
+			targets.loadBalancers[normalizeDNSName(aws.StringValue(lb.DNSName))] = true
+		}
+
+		err = elb.New(sess).DescribeLoadBalancersPages(&elb.DescribeLoadBalancersInput{}, func(page *elb.DescribeLoadBalancersOutput, lastPage bool) bool {
+			for _, lb := range page.LoadBalancerDescriptions {
+				targets.loadBalancers[normalizeDNSName(aws.StringValue(lb.DNSName))] = true
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		addresses, err := ec2.New(sess).DescribeAddresses(&ec2.DescribeAddressesInput{})
+		if err != nil {
+			return nil, err
+		}
+		for _, address := range addresses.Addresses {
+			targets.addresses[aws.StringValue(address.PublicIp)] = true
+		}
+	}
+
+	sess, err := newGlobalSession()
+	if err != nil {
+		return nil, err
+	}
+
+	distributions, err := listDistributions(cloudfront.New(sess))
+	if err != nil {
+		return nil, err
+	}
+	for _, distribution := range distributions {
+		targets.distributions[normalizeDNSName(aws.StringValue(distribution.DomainName))] = true
+	}
+
+	buckets, err := s3.New(sess).ListBuckets(&s3.ListBucketsInput{}) // ListBuckets retorna os buckets de todas as regiões
+	if err != nil {
+		return nil, err
+	}
+	for _, bucket := range buckets.Buckets {
+		targets.buckets[aws.StringValue(bucket.Name)] = true
+	}
+	return targets, nil
+}
+
+// missingTarget verifica se o destino é um recurso AWS conhecido que não existe na conta e
+// retorna a descrição do achado; destinos fora da AWS retornam vazio
+func (t *accountTargets) missingTarget(recordName string, target string) string {
+	target = strings.TrimPrefix(normalizeDNSName(target), "dualstack.") // Aliases de ELB usam o prefixo dualstack.
+
+	switch {
+	case strings.HasSuffix(target, ".elb.amazonaws.com"):
+		if !t.loadBalancers[target] {
+			return "load balancer not found"
+		}
+	case strings.HasSuffix(target, ".cloudfront.net"):
+		if !t.distributions[target] {
+			return "CloudFront distribution not found"
+		}
+	default:
+		match := s3EndpointPattern.FindStringSubmatch(target)
+		if match == nil {
+			return ""
+		}
+		bucket := match[1]
+		if bucket == "" { // Aliases para website S3 exigem bucket com o mesmo nome do registro
+			bucket = normalizeDNSName(recordName)
+		}
+		if !t.buckets[bucket] {
+			return fmt.Sprintf("S3 bucket %s not found", bucket)
+		}
+	}
+	return ""
+}
+
+// findDanglingRecords percorre todas as zonas e exibe os registros cujo alias, CNAME ou IP
+// aponta para um recurso que não existe mais na conta
+func findDanglingRecords(cmd *cobra.Command, args []string) {
+	checkIPs, _ := cmd.Flags().GetBool("ips")
+
+	targets, err := collectAccountTargets()
+	if err != nil {
+		fmt.Println("failed to list account resources,", err)
+		return
+	}
+
+	route53Client, err := newRoute53Client()
+	if err != nil {
+		fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+		return
+	}
+
+	zones, err := listHostedZones(route53Client)
+	if err != nil {
+		fmt.Println("failed to list Route 53 hosted zones,", err)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Zone", "Name", "Type", "Target", "Finding"}) // Define cabeçalhos da tabela
+	table.SetAutoWrapText(false)
+
+	for _, zone := range zones {
+		records, err := listRecordSets(route53Client, zone.Id)
+		if err != nil {
+			fmt.Println("failed to list resource record sets,", err)
+			return
+		}
+
+		for _, record := range records {
+			name := aws.StringValue(record.Name)
+			recordType := aws.StringValue(record.Type)
+
+			if record.AliasTarget != nil {
+				target := aws.StringValue(record.AliasTarget.DNSName)
+				if finding := targets.missingTarget(name, target); finding != "" {
+					table.Append([]string{zoneLabel(zone), unescapeDNSName(name), recordType + " (alias)", target, finding})
+				}
+				continue
+			}
+
+			for _, value := range record.ResourceRecords {
+				target := aws.StringValue(value.Value)
+				finding := ""
+				switch recordType {
+				case route53.RRTypeCname:
+					finding = targets.missingTarget(name, target)
+				case route53.RRTypeA, route53.RRTypeAaaa:
+					if checkIPs && !targets.addresses[target] {
+						finding = "IP is not an Elastic IP of the account"
+					}
+				}
+				if finding != "" {
+					table.Append([]string{zoneLabel(zone), unescapeDNSName(name), recordType, target, finding})
+				}
+			}
+		}
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
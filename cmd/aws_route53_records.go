@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/service/route53" // Pacote para AWS Route 53
+	"github.com/olekukonko/tablewriter"         // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                    // Pacote para criação de CLI usando Cobra
+)
+
+// route53RecordsCmd define o subcomando `route53 records` que lista os registros de uma zona
+var route53RecordsCmd = &cobra.Command{
+	Use:   "records <zone>",
+	Short: "List the record sets of a Route 53 hosted zone", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   queryRoute53Records, // Função a ser executada quando o comando `route53 records` é chamado
+}
+
+// route53FindCmd define o subcomando `route53 find` que procura registros apontando para um destino
+var route53FindCmd = &cobra.Command{
+	Use:   "find <name-or-ip>",
+	Short: "Search all hosted zones for records pointing to a hostname or IP", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   findRoute53Records, // Função a ser executada quando o comando `route53 find` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	Route53Cmd.AddCommand(route53RecordsCmd, route53FindCmd)
+}
+
+// recordHeader são as colunas usadas para exibir registros
+var recordHeader = []string{"Name", "Type", "TTL", "Values", "Alias Target", "Routing Policy", "Health Check"}
+
+// newRoute53Client cria um cliente Route 53 usando a sessão global
+func newRoute53Client() (*route53.Route53, error) {
+	sess, err := newGlobalSession()
+	if err != nil {
+		return nil, err
+	}
+	return route53.New(sess), nil
+}
+
+// listHostedZones retorna todas as zonas hospedadas da conta
+func listHostedZones(route53Client *route53.Route53) ([]*route53.HostedZone, error) {
+	zones := []*route53.HostedZone{}
+	err := route53Client.ListHostedZonesPages(&route53.ListHostedZonesInput{}, func(page *route53.ListHostedZonesOutput, lastPage bool) bool {
+		zones = append(zones, page.HostedZones...)
+		return true
+	})
+	return zones, err
+}
+
+// resolveHostedZones aceita o ID ou o nome da zona e retorna as zonas correspondentes
+// (uma zona pública e uma privada podem ter o mesmo nome)
+func resolveHostedZones(route53Client *route53.Route53, zone string) ([]*route53.HostedZone, error) {
+	zones, err := listHostedZones(route53Client)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []*route53.HostedZone{}
+	for _, hostedZone := range zones {
+		id := strings.TrimPrefix(aws.StringValue(hostedZone.Id), "/hostedzone/")
+		if id == strings.TrimPrefix(zone, "/hostedzone/") || normalizeDNSName(aws.StringValue(hostedZone.Name)) == normalizeDNSName(zone) {
+			matches = append(matches, hostedZone)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("hosted zone %s not found", zone)
+	}
+	return matches, nil
+}
+
+// listRecordSets retorna todos os registros da zona, percorrendo as páginas
+func listRecordSets(route53Client *route53.Route53, zoneID *string) ([]*route53.ResourceRecordSet, error) {
+	records := []*route53.ResourceRecordSet{}
+	input := &route53.ListResourceRecordSetsInput{HostedZoneId: zoneID}
+	err := route53Client.ListResourceRecordSetsPages(input, func(page *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+		records = append(records, page.ResourceRecordSets...)
+		return true
+	})
+	return records, err
+}
+
+// queryRoute53Records exibe os registros das zonas com o nome ou ID informado
+func queryRoute53Records(cmd *cobra.Command, args []string) {
+	route53Client, err := newRoute53Client()
+	if err != nil {
+		fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+		return
+	}
+
+	zones, err := resolveHostedZones(route53Client, args[0])
+	if err != nil {
+		fmt.Println("failed to resolve hosted zone,", err)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader(append([]string{"Zone"}, recordHeader...)) // Define cabeçalhos da tabela
+	table.SetAutoWrapText(false)
+
+	for _, zone := range zones {
+		records, err := listRecordSets(route53Client, zone.Id)
+		if err != nil {
+			fmt.Println("failed to list resource record sets,", err)
+			return
+		}
+		for _, record := range records {
+			table.Append(append([]string{zoneLabel(zone)}, recordRow(record)...)) // Adiciona a linha à tabela
+		}
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
+
+// findRoute53Records procura em todas as zonas os registros cujo valor ou alias aponta para o destino
+func findRoute53Records(cmd *cobra.Command, args []string) {
+	target := normalizeDNSName(args[0])
+
+	route53Client, err := newRoute53Client()
+	if err != nil {
+		fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+		return
+	}
+
+	zones, err := listHostedZones(route53Client)
+	if err != nil {
+		fmt.Println("failed to list Route 53 hosted zones,", err)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader(append([]string{"Zone"}, recordHeader...)) // Define cabeçalhos da tabela
+	table.SetAutoWrapText(false)
+
+	for _, zone := range zones {
+		records, err := listRecordSets(route53Client, zone.Id)
+		if err != nil {
+			fmt.Println("failed to list resource record sets,", err)
+			return
+		}
+
+		for _, record := range records {
+			matched := record.AliasTarget != nil && normalizeDNSName(aws.StringValue(record.AliasTarget.DNSName)) == target
+			for _, value := range record.ResourceRecords {
+				if normalizeDNSName(aws.StringValue(value.Value)) == target {
+					matched = true
+				}
+			}
+			if matched {
+				table.Append(append([]string{zoneLabel(zone)}, recordRow(record)...)) // Adiciona a linha à tabela
+			}
+		}
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
+
+// recordRow formata um registro nas colunas de `recordHeader`
+func recordRow(record *route53.ResourceRecordSet) []string {
+	values := []string{}
+	for _, value := range record.ResourceRecords {
+		values = append(values, aws.StringValue(value.Value))
+	}
+
+	ttl := ""
+	if record.TTL != nil {
+		ttl = fmt.Sprintf("%d", *record.TTL)
+	}
+
+	alias := ""
+	if record.AliasTarget != nil {
+		alias = aws.StringValue(record.AliasTarget.DNSName)
+		if aws.BoolValue(record.AliasTarget.EvaluateTargetHealth) {
+			alias += " (evaluate health)"
+		}
+	}
+
+	return []string{
+		unescapeDNSName(aws.StringValue(record.Name)),
+		aws.StringValue(record.Type),
+		ttl,
+		strings.Join(values, "\n"),
+		alias,
+		routingPolicy(record),
+		aws.StringValue(record.HealthCheckId),
+	}
+}
+
+// routingPolicy descreve a política de roteamento do registro a partir dos campos preenchidos
+func routingPolicy(record *route53.ResourceRecordSet) string {
+	identifier := aws.StringValue(record.SetIdentifier)
+	switch {
+	case record.Weight != nil:
+		return fmt.Sprintf("weighted %d (%s)", *record.Weight, identifier)
+	case record.Region != nil:
+		return fmt.Sprintf("latency %s (%s)", *record.Region, identifier)
+	case record.Failover != nil:
+		return fmt.Sprintf("failover %s (%s)", strings.ToLower(*record.Failover), identifier)
+	case record.GeoLocation != nil:
+		location := record.GeoLocation
+		parts := []string{}
+		for _, part := range []*string{location.ContinentCode, location.CountryCode, location.SubdivisionCode} {
+			if part != nil {
+				parts = append(parts, *part)
+			}
+		}
+		return fmt.Sprintf("geolocation %s (%s)", strings.Join(parts, "/"), identifier)
+	case record.CidrRoutingConfig != nil:
+		return fmt.Sprintf("ip-based %s (%s)", aws.StringValue(record.CidrRoutingConfig.LocationName), identifier)
+	case record.MultiValueAnswer != nil && *record.MultiValueAnswer:
+		return fmt.Sprintf("multivalue (%s)", identifier)
+	}
+	return "simple"
+}
+
+// zoneLabel identifica a zona pelo nome, indicando quando é privada
+func zoneLabel(zone *route53.HostedZone) string {
+	if zone.Config != nil && aws.BoolValue(zone.Config.PrivateZone) {
+		return aws.StringValue(zone.Name) + " (private)"
+	}
+	return aws.StringValue(zone.Name)
+}
+
+// normalizeDNSName remove o ponto final e padroniza em minúsculas para comparação
+func normalizeDNSName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(unescapeDNSName(name), "."))
+}
+
+// unescapeDNSName converte os escapes octais usados pelo Route 53 (ex: \052 para *)
+func unescapeDNSName(name string) string {
+	var builder strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\\' && i+3 < len(name) {
+			if code, err := strconv.ParseUint(name[i+1:i+4], 8, 8); err == nil {
+				builder.WriteByte(byte(code))
+				i += 3
+				continue
+			}
+		}
+		builder.WriteByte(name[i])
+	}
+	return builder.String()
+}
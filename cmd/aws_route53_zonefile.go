@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/service/route53" // Pacote para AWS Route 53
+	"github.com/spf13/cobra"                    // Pacote para criação de CLI usando Cobra
+)
+
+// route53ExportCmd define o subcomando `route53 export` que gera um arquivo de zona BIND
+var route53ExportCmd = &cobra.Command{
+	Use:   "export <zone>",
+	Short: "Export the record sets of a hosted zone as a BIND zone file", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   exportRoute53Zone, // Função a ser executada quando o comando `route53 export` é chamado
+}
+
+// route53ImportCmd define o subcomando `route53 import` que aplica um arquivo de zona BIND
+var route53ImportCmd = &cobra.Command{
+	Use:   "import <file.zone>",
+	Short: "Import a BIND zone file into a hosted zone, or preview the change batch with --dry-run", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   importRoute53Zone, // Função a ser executada quando o comando `route53 import` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	route53ExportCmd.Flags().Bool("flatten", false, "Resolve alias records and write their current addresses instead of comments")
+	route53ExportCmd.Flags().StringP("output", "o", "", "Write the zone file to this path instead of stdout")
+	route53ImportCmd.Flags().Bool("dry-run", false, "Print the change batch without submitting it")
+	route53ImportCmd.Flags().String("zone", "", "Hosted zone name or ID (also the origin when the file has no $ORIGIN)")
+	route53ImportCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	Route53Cmd.AddCommand(route53ExportCmd, route53ImportCmd)
+}
+
+// route53ChangeBatchSize limita a quantidade de alterações enviadas em cada ChangeResourceRecordSets
+const route53ChangeBatchSize = 100
+
+// flattenedTTL é o TTL usado para aliases convertidos em endereços, já que aliases não têm TTL
+const flattenedTTL = 60
+
+// exportRoute53Zone escreve os registros da zona no formato BIND; aliases viram comentários
+// ou, com `--flatten`, os endereços para os quais resolvem no momento
+func exportRoute53Zone(cmd *cobra.Command, args []string) {
+	flatten, _ := cmd.Flags().GetBool("flatten")
+	output, _ := cmd.Flags().GetString("output")
+
+	route53Client, err := newRoute53Client()
+	if err != nil {
+		fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+		return
+	}
+
+	zones, err := resolveHostedZones(route53Client, args[0])
+	if err != nil {
+		fmt.Println("failed to resolve hosted zone,", err)
+		return
+	}
+	if len(zones) > 1 {
+		fmt.Printf("%s matches %d hosted zones, use the hosted zone ID instead\n", args[0], len(zones))
+		return
+	}
+	zone := zones[0]
+
+	records, err := listRecordSets(route53Client, zone.Id)
+	if err != nil {
+		fmt.Println("failed to list resource record sets,", err)
+		return
+	}
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "; Exported from Route 53 hosted zone %s (%s)\n", zoneLabel(zone), strings.TrimPrefix(aws.StringValue(zone.Id), "/hostedzone/"))
+	fmt.Fprintf(&buffer, "$ORIGIN %s\n\n", aws.StringValue(zone.Name))
+
+	for _, record := range records {
+		name := unescapeDNSName(aws.StringValue(record.Name))
+		recordType := aws.StringValue(record.Type)
+
+		if policy := routingPolicy(record); policy != "simple" { // O formato BIND não representa políticas de roteamento
+			fmt.Fprintf(&buffer, "; routing policy: %s\n", policy)
+		}
+
+		if record.AliasTarget != nil {
+			target := aws.StringValue(record.AliasTarget.DNSName)
+			if !flatten {
+				fmt.Fprintf(&buffer, "; %s\tALIAS\t%s\t%s\n", name, recordType, target)
+				continue
+			}
+
+			addresses, err := flattenAlias(target, recordType)
+			if err != nil || len(addresses) == 0 {
+				fmt.Fprintf(&buffer, "; %s\tALIAS\t%s\t%s (could not resolve: %v)\n", name, recordType, target, err)
+				continue
+			}
+			fmt.Fprintf(&buffer, "; flattened from alias to %s\n", target)
+			for _, address := range addresses {
+				fmt.Fprintf(&buffer, "%s\t%d\tIN\t%s\t%s\n", name, flattenedTTL, recordType, address)
+			}
+			continue
+		}
+
+		for _, value := range record.ResourceRecords {
+			fmt.Fprintf(&buffer, "%s\t%d\tIN\t%s\t%s\n", name, aws.Int64Value(record.TTL), recordType, aws.StringValue(value.Value))
+		}
+	}
+
+	if output == "" {
+		fmt.Print(buffer.String())
+		return
+	}
+	if err := os.WriteFile(output, buffer.Bytes(), 0644); err != nil {
+		fmt.Println("failed to write zone file,", err)
+		return
+	}
+	fmt.Printf("Wrote %d record sets to %s\n", len(records), output)
+}
+
+// flattenAlias resolve o destino de um alias e retorna os endereços do tipo do registro (A ou AAAA)
+func flattenAlias(target string, recordType string) ([]string, error) {
+	ips, err := net.LookupIP(strings.TrimSuffix(target, "."))
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := []string{}
+	for _, ip := range ips {
+		if ipv4 := ip.To4() != nil; (recordType == route53.RRTypeA) == ipv4 {
+			addresses = append(addresses, ip.String())
+		}
+	}
+	return addresses, nil
+}
+
+// importRoute53Zone lê o arquivo de zona e exibe ou aplica as alterações como UPSERT
+func importRoute53Zone(cmd *cobra.Command, args []string) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	zoneName, _ := cmd.Flags().GetString("zone")
+	skipConfirmation, _ := cmd.Flags().GetBool("yes")
+
+	if !dryRun && zoneName == "" {
+		fmt.Println("--zone is required unless --dry-run is set")
+		return
+	}
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		fmt.Println("failed to open zone file,", err)
+		return
+	}
+	defer file.Close()
+
+	var zone *route53.HostedZone
+	var route53Client *route53.Route53
+	origin := zoneName
+	if !dryRun {
+		if route53Client, err = newRoute53Client(); err != nil {
+			fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+			return
+		}
+
+		zones, err := resolveHostedZones(route53Client, zoneName)
+		if err != nil {
+			fmt.Println("failed to resolve hosted zone,", err)
+			return
+		}
+		if len(zones) > 1 {
+			fmt.Printf("%s matches %d hosted zones, use the hosted zone ID instead\n", zoneName, len(zones))
+			return
+		}
+		zone = zones[0]
+		origin = aws.StringValue(zone.Name)
+	}
+
+	recordSets, err := parseZoneFile(file, origin)
+	if err != nil {
+		fmt.Println("failed to parse zone file,", err)
+		return
+	}
+
+	apex := normalizeDNSName(origin)
+	for _, recordSet := range recordSets {
+		if aws.StringValue(recordSet.Type) == route53.RRTypeSoa { // O SOA identifica o apex quando o arquivo define $ORIGIN
+			apex = normalizeDNSName(aws.StringValue(recordSet.Name))
+		}
+	}
+
+	changes := []*route53.Change{}
+	for _, recordSet := range recordSets {
+		recordType := aws.StringValue(recordSet.Type)
+		if recordType == route53.RRTypeSoa || (recordType == route53.RRTypeNs && normalizeDNSName(aws.StringValue(recordSet.Name)) == apex) {
+			continue // SOA e NS do apex são gerenciados pelo Route 53
+		}
+		changes = append(changes, &route53.Change{Action: aws.String(route53.ChangeActionUpsert), ResourceRecordSet: recordSet})
+	}
+
+	for start := 0; start < len(changes); start += route53ChangeBatchSize {
+		end := start + route53ChangeBatchSize
+		if end > len(changes) {
+			end = len(changes)
+		}
+		changeBatch := &route53.ChangeBatch{
+			Comment: aws.String("Imported from " + args[0]),
+			Changes: changes[start:end],
+		}
+
+		if dryRun {
+			encoded, err := json.MarshalIndent(changeBatchJSON(changeBatch), "", "  ")
+			if err != nil {
+				fmt.Println("failed to encode change batch,", err)
+				return
+			}
+			fmt.Println(string(encoded))
+			continue
+		}
+
+		if start == 0 && !skipConfirmation && !confirm(fmt.Sprintf("UPSERT %d record sets into %s?", len(changes), zoneLabel(zone))) {
+			return
+		}
+
+		result, err := route53Client.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+			HostedZoneId: zone.Id,
+			ChangeBatch:  changeBatch,
+		})
+		if err != nil {
+			fmt.Println("failed to change resource record sets,", err)
+			return
+		}
+		fmt.Printf("Submitted %d changes: %s (%s)\n", end-start, aws.StringValue(result.ChangeInfo.Id), aws.StringValue(result.ChangeInfo.Status))
+	}
+}
+
+// changeBatchDocument e os tipos abaixo reproduzem o formato JSON aceito por
+// `aws route53 change-resource-record-sets --change-batch`, limitado aos campos que um arquivo
+// de zona pode preencher
+type changeBatchDocument struct {
+	Comment string           `json:"Comment,omitempty"`
+	Changes []changeDocument `json:"Changes"`
+}
+
+type changeDocument struct {
+	Action            string            `json:"Action"`
+	ResourceRecordSet recordSetDocument `json:"ResourceRecordSet"`
+}
+
+type recordSetDocument struct {
+	Name            string                   `json:"Name"`
+	Type            string                   `json:"Type"`
+	TTL             int64                    `json:"TTL"`
+	ResourceRecords []resourceRecordDocument `json:"ResourceRecords"`
+}
+
+type resourceRecordDocument struct {
+	Value string `json:"Value"`
+}
+
+// changeBatchJSON converte o ChangeBatch do SDK, cujos tipos serializariam todos os campos nulos
+func changeBatchJSON(changeBatch *route53.ChangeBatch) *changeBatchDocument {
+	document := &changeBatchDocument{Comment: aws.StringValue(changeBatch.Comment), Changes: []changeDocument{}}
+	for _, change := range changeBatch.Changes {
+		recordSet := recordSetDocument{
+			Name:            aws.StringValue(change.ResourceRecordSet.Name),
+			Type:            aws.StringValue(change.ResourceRecordSet.Type),
+			TTL:             aws.Int64Value(change.ResourceRecordSet.TTL),
+			ResourceRecords: []resourceRecordDocument{},
+		}
+		for _, record := range change.ResourceRecordSet.ResourceRecords {
+			recordSet.ResourceRecords = append(recordSet.ResourceRecords, resourceRecordDocument{Value: aws.StringValue(record.Value)})
+		}
+		document.Changes = append(document.Changes, changeDocument{Action: aws.StringValue(change.Action), ResourceRecordSet: recordSet})
+	}
+	return document
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"lookr/deps" // Importação de pacotes locais ou dependências
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2" // Pacote para Amazon EC2
+	"github.com/olekukonko/tablewriter"     // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                // Pacote para criação de CLI usando Cobra
+)
+
+// SnapshotsCmd define o comando `snapshots` para o CLI
+var SnapshotsCmd = &cobra.Command{
+	Use:   "snapshots",
+	Short: "Query owned EBS snapshots in different regions and flag orphaned ones", // Descrição breve do comando
+	Run:   querySnapshots, // Função a ser executada quando o comando `snapshots` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	SnapshotsCmd.Flags().Bool("orphans", false, "Show only snapshots whose source volume and AMI no longer exist")
+	rootCmd.AddCommand(SnapshotsCmd) // Adiciona o comando `snapshots` como um subcomando do comando raiz
+}
+
+// querySnapshots é a função que executa a lógica para consultar snapshots EBS
+func querySnapshots(cmd *cobra.Command, args []string) {
+	onlyOrphans, _ := cmd.Flags().GetBool("orphans")
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Snapshot ID", "Region", "Source Volume", "Size (GB)", "Age (days)", "State", "Encryption", "AMI", "Orphan"}) // Define cabeçalhos da tabela
+
+	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
+	for _, region := range AuthRegions { // Itera sobre cada região autorizada
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região atual
+		})
+
+		if err != nil {
+			fmt.Println("failed to create session,", err) // Imprime erro se a sessão não puder ser criada
+			return
+		}
+
+		ec2Client := ec2.New(sess) // Cria um novo cliente EC2 com a sessão configurada
+
+		snapshots := []*ec2.Snapshot{}
+		input := &ec2.DescribeSnapshotsInput{
+			OwnerIds: []*string{aws.String("self")}, // Restringe aos snapshots da própria conta
+		}
+		err = ec2Client.DescribeSnapshotsPages(input, func(page *ec2.DescribeSnapshotsOutput, lastPage bool) bool {
+			snapshots = append(snapshots, page.Snapshots...)
+			return true
+		})
+		if err != nil {
+			fmt.Println("failed to describe EBS snapshots,", err) // Imprime erro se a descrição falhar
+			return
+		}
+
+		if len(snapshots) == 0 {
+			continue // Evita consultar volumes e AMIs em regiões sem snapshots
+		}
+
+		volumes := map[string]bool{}
+		err = ec2Client.DescribeVolumesPages(&ec2.DescribeVolumesInput{}, func(page *ec2.DescribeVolumesOutput, lastPage bool) bool {
+			for _, volume := range page.Volumes {
+				volumes[aws.StringValue(volume.VolumeId)] = true
+			}
+			return true
+		})
+		if err != nil {
+			fmt.Println("failed to describe Amazon EBS volumes,", err) // Imprime erro se a descrição falhar
+			return
+		}
+
+		images, err := ownedImages(ec2Client) // Lista as AMIs da conta para relacionar com os snapshots
+		if err != nil {
+			fmt.Println("failed to describe AMIs,", err) // Imprime erro se a descrição falhar
+			return
+		}
+
+		imageBySnapshot := map[string]string{}
+		for _, image := range images {
+			for _, mapping := range image.BlockDeviceMappings {
+				if mapping.Ebs != nil && mapping.Ebs.SnapshotId != nil {
+					imageBySnapshot[*mapping.Ebs.SnapshotId] = aws.StringValue(image.ImageId)
+				}
+			}
+		}
+
+		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
+
+		for _, snapshot := range snapshots { // Itera sobre cada snapshot listado
+			volumeID := aws.StringValue(snapshot.VolumeId)
+			imageID := imageBySnapshot[aws.StringValue(snapshot.SnapshotId)]
+
+			// Um snapshot é órfão quando nem o volume de origem nem uma AMI o referenciam
+			orphan := !volumes[volumeID] && imageID == ""
+			if onlyOrphans && !orphan {
+				continue
+			}
+
+			// Cria uma linha com os detalhes do snapshot para adicionar à tabela
+			row := []string{
+				aws.StringValue(snapshot.SnapshotId),
+				regionName,
+				volumeID,
+				fmt.Sprintf("%d", aws.Int64Value(snapshot.VolumeSize)),
+				ageInDays(aws.TimeValue(snapshot.StartTime)),
+				aws.StringValue(snapshot.State),
+				yesNo(aws.BoolValue(snapshot.Encrypted)),
+				imageID,
+				yesNo(orphan),
+			}
+			table.Append(row) // Adiciona a linha à tabela
+		}
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
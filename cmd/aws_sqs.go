@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"lookr/deps" // Importação de pacotes locais ou dependências
 	"os"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch" // Pacote para Amazon CloudWatch
 	"github.com/aws/aws-sdk-go/service/sqs" // Pacote para AWS SQS
 	"github.com/olekukonko/tablewriter" // Pacote para formatação de tabelas
 	"github.com/spf13/cobra" // Pacote para criação de CLI usando Cobra
@@ -30,7 +32,7 @@ func init() {
 // querySQS é a função que executa a lógica para consultar filas Amazon SQS
 func querySQS(cmd *cobra.Command, args []string) {
 	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
-	table.SetHeader([]string{"Queue Name", "Region", "Visibility Timeout", "Approximate Messages", "Created Timestamp", "Arn"}) // Define cabeçalhos da tabela
+	table.SetHeader([]string{"Queue Name", "Region", "Visibility Timeout", "Approximate Messages", "In Flight", "Delayed", "FIFO", "DLQ", "Max Receives", "Retention", "Encryption", "Oldest Message", "Health", "Created Timestamp", "Arn"}) // Define cabeçalhos da tabela
 
 	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
 	for _, region := range AuthRegions { // Itera sobre cada região autorizada
@@ -44,25 +46,25 @@ func querySQS(cmd *cobra.Command, args []string) {
 		}
 
 		sqsClient := sqs.New(sess) // Cria um novo cliente SQS com a sessão configurada
+		cwClient := cloudwatch.New(sess) // Cria um novo cliente CloudWatch para as métricas das filas
 
+		queueURLs := []*string{}
 		input := &sqs.ListQueuesInput{} // Cria um input para listar filas SQS
-		result, err := sqsClient.ListQueues(input) // Lista as filas SQS na região atual
+		err = sqsClient.ListQueuesPages(input, func(page *sqs.ListQueuesOutput, lastPage bool) bool { // Lista as filas SQS na região atual
+			queueURLs = append(queueURLs, page.QueueUrls...)
+			return true
+		})
 		if err != nil {
 			fmt.Println("failed to list Amazon SQS queues,", err) // Imprime erro se a listagem falhar
 			return
 		}
 
-		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
-
-		for _, queueURL := range result.QueueUrls { // Itera sobre cada URL de fila na lista de URLs
+		queues := []map[string]*string{}
+		deadLetterQueues := map[string]bool{} // ARNs das filas usadas como DLQ por outra fila
+		for _, queueURL := range queueURLs { // Itera sobre cada URL de fila na lista de URLs
 			getQueueAttributesInput := &sqs.GetQueueAttributesInput{
-				QueueUrl: queueURL, // URL da fila atual
-				AttributeNames: []*string{
-					aws.String("VisibilityTimeout"), // Atributo: VisibilityTimeout
-					aws.String("ApproximateNumberOfMessages"), // Atributo: ApproximateNumberOfMessages
-					aws.String("CreatedTimestamp"), // Atributo: CreatedTimestamp
-					aws.String("Arn"), // Atributo: Arn
-				},
+				QueueUrl:       queueURL, // URL da fila atual
+				AttributeNames: []*string{aws.String(sqs.QueueAttributeNameAll)}, // Todos os atributos da fila
 			}
 
 			attributes, err := sqsClient.GetQueueAttributes(getQueueAttributesInput)
@@ -71,13 +73,57 @@ func querySQS(cmd *cobra.Command, args []string) {
 				return
 			}
 
+			if target, _ := parseRedrivePolicy(attributes.Attributes["RedrivePolicy"]); target != "" {
+				deadLetterQueues[target] = true
+			}
+			queues = append(queues, attributes.Attributes)
+		}
+
+		regionName := deps.GetRegionName(region) // Obtém o nome da região atual
+		metricsAvailable := true
+
+		for i, attributes := range queues {
+			queueName := queueNameFromURL(*queueURLs[i]) // Nome da fila obtido da URL
+			attribute := func(name string) string { return aws.StringValue(attributes[name]) }
+
+			target, maxReceives := parseRedrivePolicy(attributes["RedrivePolicy"])
+
+			isDeadLetterQueue := deadLetterQueues[attribute("QueueArn")]
+			oldestMessage, health := "", ""
+			if isDeadLetterQueue { // Depende apenas dos atributos da fila, mesmo sem acesso ao CloudWatch
+				health = deadLetterQueueHealth(attribute("ApproximateNumberOfMessages"))
+			}
+			if metricsAvailable { // Sem permissão no CloudWatch as colunas de métricas ficam vazias
+				age, ok, err := latestMetricValue(cwClient, "AWS/SQS", "ApproximateAgeOfOldestMessage", cloudwatch.StatisticMaximum,
+					map[string]string{"QueueName": queueName}, time.Hour)
+				if ok {
+					oldestMessage = (time.Duration(age) * time.Second).String()
+				}
+				if err == nil && !isDeadLetterQueue {
+					health, err = queueHealth(cwClient, queueName)
+				}
+				if err != nil {
+					fmt.Println("failed to get queue metrics, skipping metric columns,", err)
+					metricsAvailable = false
+				}
+			}
+
 			row := []string{
-				queueNameFromURL(*queueURL), // Nome da fila obtido da URL
+				queueName,
 				regionName, // Nome da região
-				*attributes.Attributes["VisibilityTimeout"], // Timeout de visibilidade
-				*attributes.Attributes["ApproximateNumberOfMessages"], // Número aproximado de mensagens
-				timestampToTimeString(*attributes.Attributes["CreatedTimestamp"]), // Timestamp de criação formatado
-				*attributes.Attributes["Arn"], // ARN da fila
+				attribute("VisibilityTimeout"), // Timeout de visibilidade
+				attribute("ApproximateNumberOfMessages"), // Número aproximado de mensagens
+				attribute("ApproximateNumberOfMessagesNotVisible"), // Mensagens em processamento
+				attribute("ApproximateNumberOfMessagesDelayed"), // Mensagens com entrega atrasada
+				yesNo(attribute("FifoQueue") == "true"),
+				queueNameFromARN(target), // Nome da DLQ obtido do ARN
+				maxReceives,
+				secondsToDays(attribute("MessageRetentionPeriod")),
+				queueEncryption(attributes),
+				oldestMessage,
+				health,
+				timestampToTimeString(attribute("CreatedTimestamp")), // Timestamp de criação formatado
+				attribute("QueueArn"), // ARN da fila
 			}
 			table.Append(row) // Adiciona a linha à tabela
 		}
@@ -85,6 +131,73 @@ func querySQS(cmd *cobra.Command, args []string) {
 	table.Render() // Renderiza a tabela com os resultados
 }
 
+// parseRedrivePolicy extrai o ARN da DLQ e o maxReceiveCount da política de redrive da fila
+func parseRedrivePolicy(policy *string) (string, string) {
+	if policy == nil {
+		return "", ""
+	}
+
+	var redrive struct {
+		DeadLetterTargetArn string      `json:"deadLetterTargetArn"`
+		MaxReceiveCount     json.Number `json:"maxReceiveCount"`
+	}
+	if err := json.Unmarshal([]byte(*policy), &redrive); err != nil {
+		return "", ""
+	}
+	return redrive.DeadLetterTargetArn, redrive.MaxReceiveCount.String()
+}
+
+// queueEncryption descreve a criptografia em repouso da fila (SSE-SQS ou SSE-KMS)
+func queueEncryption(attributes map[string]*string) string {
+	if key := aws.StringValue(attributes["KmsMasterKeyId"]); key != "" {
+		return "SSE-KMS (" + key + ")"
+	}
+	if aws.StringValue(attributes["SqsManagedSseEnabled"]) == "true" {
+		return "SSE-SQS"
+	}
+	return "None"
+}
+
+// deadLetterQueueHealth sinaliza DLQs com mensagens visíveis
+func deadLetterQueueHealth(visible string) string {
+	if visible != "" && visible != "0" {
+		return "DLQ has messages"
+	}
+	return "OK"
+}
+
+// queueHealth deriva o estado da fila pelo CloudWatch: filas cujo backlog cresceu
+// continuamente na última hora são sinalizadas
+func queueHealth(cwClient *cloudwatch.CloudWatch, queueName string) (string, error) {
+	series, err := metricSeries(cwClient, "AWS/SQS", "ApproximateNumberOfMessagesVisible", cloudwatch.StatisticMaximum,
+		map[string]string{"QueueName": queueName}, time.Hour)
+	if err != nil {
+		return "", err
+	}
+	if len(series) < 3 {
+		return "OK", nil // Poucos pontos para avaliar tendência
+	}
+
+	for i := 1; i < len(series); i++ {
+		if series[i] < series[i-1] {
+			return "OK", nil // O backlog diminuiu em algum momento
+		}
+	}
+	if series[len(series)-1] > series[0] {
+		return "Backlog growing", nil
+	}
+	return "OK", nil
+}
+
+// secondsToDays converte uma duração em segundos (string) em dias
+func secondsToDays(seconds string) string {
+	value, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return seconds // Retorna o valor original se a conversão falhar
+	}
+	return fmt.Sprintf("%gd", float64(value)/86400)
+}
+
 // queueNameFromURL extrai o nome da fila a partir da URL da fila
 func queueNameFromURL(url string) string {
 	parts := splitLast(url, "/")
@@ -94,6 +207,11 @@ func queueNameFromURL(url string) string {
 	return url
 }
 
+// queueNameFromARN extrai o nome da fila a partir do ARN da fila
+func queueNameFromARN(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
+
 // splitLast divide a string `s` pelo separador `sep` e retorna a última parte
 func splitLast(s, sep string) []string {
 	parts := strings.Split(s, sep)
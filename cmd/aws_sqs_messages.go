@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"fmt"
+	"lookr/deps" // Importação de pacotes locais ou dependências
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs" // Pacote para AWS SQS
+	"github.com/olekukonko/tablewriter"     // Pacote para formatação de tabelas
+	"github.com/spf13/cobra"                // Pacote para criação de CLI usando Cobra
+)
+
+// sqsPeekCmd define o subcomando `sqs peek` que lê mensagens sem removê-las da fila
+var sqsPeekCmd = &cobra.Command{
+	Use:   "peek <queue>",
+	Short: "Print messages of a queue without deleting them", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   peekSQS, // Função a ser executada quando o comando `sqs peek` é chamado
+}
+
+// sqsRedriveCmd define o subcomando `sqs redrive` que move as mensagens de uma DLQ
+var sqsRedriveCmd = &cobra.Command{
+	Use:   "redrive <dlq>",
+	Short: "Move messages from a dead-letter queue back to their source queue", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   redriveSQS, // Função a ser executada quando o comando `sqs redrive` é chamado
+}
+
+// sqsPurgeCmd define o subcomando `sqs purge` que apaga todas as mensagens de uma fila
+var sqsPurgeCmd = &cobra.Command{
+	Use:   "purge <queue>",
+	Short: "Delete all messages of a queue after confirmation", // Descrição breve do comando
+	Args:  cobra.ExactArgs(1),
+	Run:   purgeSQS, // Função a ser executada quando o comando `sqs purge` é chamado
+}
+
+// init é chamado antes da execução do programa principal
+func init() {
+	sqsPeekCmd.Flags().Int64("count", 10, "Number of messages to receive (1-10). Each peek increments ApproximateReceiveCount and may move messages to the DLQ of queues with a redrive policy")
+	sqsPeekCmd.Flags().Int64("visibility", 0, "Visibility timeout in seconds applied to received messages")
+	sqsRedriveCmd.Flags().String("destination", "", "Destination queue (defaults to the original source queue)")
+	sqsRedriveCmd.Flags().Int64("rate", 0, "Maximum number of messages moved per second (0 lets SQS decide)")
+	sqsPurgeCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+
+	SqsCmd.AddCommand(sqsPeekCmd, sqsRedriveCmd, sqsPurgeCmd)
+}
+
+// resolveQueue aceita a URL ou o nome da fila e retorna o cliente da região onde ela existe
+// junto com a URL. URLs são consultadas apenas na região do host; nomes são procurados em todas
+// as regiões autorizadas e existir em mais de uma é um erro.
+func resolveQueue(queue string) (*sqs.SQS, string, error) {
+	if strings.Contains(queue, "://") {
+		region, err := regionFromQueueURL(queue)
+		if err != nil {
+			return nil, "", err
+		}
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região da URL
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return sqs.New(sess), queue, nil
+	}
+
+	var matchClient *sqs.SQS
+	matchURL, regions := "", []string{} // Regiões onde existe uma fila com esse nome
+
+	AuthRegions := deps.AuthRegions() // Obtém as regiões autorizadas para autenticação
+	for _, region := range AuthRegions { // Itera sobre cada região autorizada
+		sess, err := session.NewSession(&aws.Config{
+			Region: aws.String(region), // Configura a sessão com a região atual
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		sqsClient := sqs.New(sess) // Cria um novo cliente SQS com a sessão configurada
+
+		input := &sqs.ListQueuesInput{QueueNamePrefix: aws.String(queue)}
+		err = sqsClient.ListQueuesPages(input, func(page *sqs.ListQueuesOutput, lastPage bool) bool {
+			for _, queueURL := range page.QueueUrls {
+				if queueNameFromURL(*queueURL) == queue {
+					matchClient, matchURL = sqsClient, *queueURL
+					regions = append(regions, region)
+					return false
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	switch len(regions) {
+	case 0:
+		return nil, "", fmt.Errorf("queue %s not found in any region", queue)
+	case 1:
+		return matchClient, matchURL, nil
+	}
+	return nil, "", fmt.Errorf("queue %s exists in %s, use the queue URL instead", queue, strings.Join(regions, ", "))
+}
+
+// regionFromQueueURL extrai a região do host da URL da fila, nos formatos
+// sqs.<região>.amazonaws.com e <região>.queue.amazonaws.com (queue.amazonaws.com é us-east-1)
+func regionFromQueueURL(queueURL string) (string, error) {
+	parsed, err := url.Parse(queueURL)
+	if err != nil {
+		return "", err
+	}
+
+	labels := strings.Split(parsed.Hostname(), ".")
+	switch {
+	case len(labels) >= 3 && labels[0] == "sqs":
+		return labels[1], nil
+	case len(labels) >= 3 && labels[1] == "queue":
+		return labels[0], nil
+	case parsed.Hostname() == "queue.amazonaws.com":
+		return "us-east-1", nil
+	}
+	return "", fmt.Errorf("cannot determine the region of queue URL %s", queueURL)
+}
+
+// queueArn obtém o ARN da fila a partir da sua URL
+func queueArn(sqsClient *sqs.SQS, queueURL string) (string, error) {
+	attributes, err := sqsClient.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(queueURL),
+		AttributeNames: []*string{aws.String(sqs.QueueAttributeNameQueueArn)},
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(attributes.Attributes[sqs.QueueAttributeNameQueueArn]), nil
+}
+
+// peekSQS recebe mensagens com timeout de visibilidade mínimo e as imprime sem apagá-las
+func peekSQS(cmd *cobra.Command, args []string) {
+	count, _ := cmd.Flags().GetInt64("count")
+	visibility, _ := cmd.Flags().GetInt64("visibility")
+
+	if count < 1 || count > 10 {
+		fmt.Println("--count must be between 1 and 10")
+		return
+	}
+
+	sqsClient, queueURL, err := resolveQueue(args[0])
+	if err != nil {
+		fmt.Println("failed to resolve queue,", err)
+		return
+	}
+
+	result, err := sqsClient.ReceiveMessage(&sqs.ReceiveMessageInput{
+		QueueUrl:              aws.String(queueURL),
+		MaxNumberOfMessages:   aws.Int64(count),
+		VisibilityTimeout:     aws.Int64(visibility), // Mantém as mensagens visíveis para os consumidores
+		AttributeNames:        []*string{aws.String(sqs.QueueAttributeNameAll)},
+		MessageAttributeNames: []*string{aws.String(sqs.QueueAttributeNameAll)},
+	})
+	if err != nil {
+		fmt.Println("failed to receive messages,", err)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout) // Cria um novo escritor de tabela que escreve para os.Stdout
+	table.SetHeader([]string{"Message ID", "Sent", "Receive Count", "Attributes", "Body"}) // Define cabeçalhos da tabela
+
+	for _, message := range result.Messages {
+		attributes := []string{}
+		for name, value := range message.MessageAttributes {
+			attributes = append(attributes, fmt.Sprintf("%s=%s", name, aws.StringValue(value.StringValue)))
+		}
+		sort.Strings(attributes)
+
+		row := []string{
+			aws.StringValue(message.MessageId),
+			millisecondsToTimeString(aws.StringValue(message.Attributes["SentTimestamp"])), // SentTimestamp vem em milissegundos
+			aws.StringValue(message.Attributes["ApproximateReceiveCount"]),
+			strings.Join(attributes, ", "),
+			aws.StringValue(message.Body),
+		}
+		table.Append(row) // Adiciona a linha à tabela
+	}
+	table.Render() // Renderiza a tabela com os resultados
+}
+
+// millisecondsToTimeString converte um timestamp em milissegundos para uma string de tempo formatada
+func millisecondsToTimeString(timestamp string) string {
+	milliseconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return timestamp // Retorna o timestamp original se a conversão falhar
+	}
+	return time.UnixMilli(milliseconds).String()
+}
+
+// redriveSQS inicia uma tarefa de movimentação de mensagens e acompanha o progresso até o fim
+func redriveSQS(cmd *cobra.Command, args []string) {
+	destination, _ := cmd.Flags().GetString("destination")
+	rate, _ := cmd.Flags().GetInt64("rate")
+
+	sqsClient, queueURL, err := resolveQueue(args[0])
+	if err != nil {
+		fmt.Println("failed to resolve queue,", err)
+		return
+	}
+
+	sourceArn, err := queueArn(sqsClient, queueURL)
+	if err != nil {
+		fmt.Println("failed to get queue attributes,", err)
+		return
+	}
+
+	input := &sqs.StartMessageMoveTaskInput{
+		SourceArn: aws.String(sourceArn),
+	}
+	if destination != "" {
+		destinationClient, destinationURL, err := resolveQueue(destination)
+		if err != nil {
+			fmt.Println("failed to resolve destination queue,", err)
+			return
+		}
+		destinationArn, err := queueArn(destinationClient, destinationURL)
+		if err != nil {
+			fmt.Println("failed to get queue attributes,", err)
+			return
+		}
+		input.DestinationArn = aws.String(destinationArn)
+	}
+	if rate > 0 {
+		input.MaxNumberOfMessagesPerSecond = aws.Int64(rate)
+	}
+
+	if _, err := sqsClient.StartMessageMoveTask(input); err != nil {
+		fmt.Println("failed to start message move task,", err)
+		return
+	}
+
+	for { // Acompanha a tarefa mais recente da fila até que ela deixe de executar
+		tasks, err := sqsClient.ListMessageMoveTasks(&sqs.ListMessageMoveTasksInput{
+			SourceArn:  aws.String(sourceArn),
+			MaxResults: aws.Int64(1),
+		})
+		if err != nil {
+			fmt.Println("failed to list message move tasks,", err)
+			return
+		}
+		if len(tasks.Results) == 0 {
+			fmt.Println("message move task not found")
+			return
+		}
+
+		task := tasks.Results[0]
+		fmt.Printf("%s: %d/%d messages moved\n", aws.StringValue(task.Status),
+			aws.Int64Value(task.ApproximateNumberOfMessagesMoved), aws.Int64Value(task.ApproximateNumberOfMessagesToMove))
+
+		if aws.StringValue(task.Status) != "RUNNING" {
+			if reason := aws.StringValue(task.FailureReason); reason != "" {
+				fmt.Println("message move task failed,", reason)
+			}
+			return
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// purgeSQS apaga todas as mensagens da fila após confirmação do usuário
+func purgeSQS(cmd *cobra.Command, args []string) {
+	skipConfirmation, _ := cmd.Flags().GetBool("yes")
+
+	sqsClient, queueURL, err := resolveQueue(args[0])
+	if err != nil {
+		fmt.Println("failed to resolve queue,", err)
+		return
+	}
+
+	if !skipConfirmation && !confirm(fmt.Sprintf("Delete ALL messages from %s?", queueURL)) {
+		fmt.Println("purge cancelled")
+		return
+	}
+
+	if _, err := sqsClient.PurgeQueue(&sqs.PurgeQueueInput{QueueUrl: aws.String(queueURL)}); err != nil {
+		fmt.Println("failed to purge queue,", err)
+		return
+	}
+	fmt.Println("purge requested for", queueURL)
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/service/cloudwatch" // Pacote para Amazon CloudWatch
+)
+
+// latestMetricValue retorna a estatística mais recente de uma métrica do CloudWatch dentro da janela
+// informada. O segundo retorno é falso quando não há pontos de dados no período.
+func latestMetricValue(cwClient *cloudwatch.CloudWatch, namespace, metric, statistic string, dimensions map[string]string, window time.Duration) (float64, bool, error) {
+	series, err := metricSeries(cwClient, namespace, metric, statistic, dimensions, window)
+	if err != nil || len(series) == 0 {
+		return 0, false, err
+	}
+	return series[len(series)-1], true, nil
+}
+
+// metricSeries retorna os valores de uma estatística do CloudWatch em ordem cronológica,
+// com pontos de um minuto dentro da janela informada
+func metricSeries(cwClient *cloudwatch.CloudWatch, namespace, metric, statistic string, dimensions map[string]string, window time.Duration) ([]float64, error) {
+	dims := []*cloudwatch.Dimension{}
+	for name, value := range dimensions {
+		dims = append(dims, &cloudwatch.Dimension{Name: aws.String(name), Value: aws.String(value)})
+	}
+
+	now := time.Now()
+	input := &cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String(namespace),
+		MetricName: aws.String(metric),
+		Dimensions: dims,
+		StartTime:  aws.Time(now.Add(-window)),
+		EndTime:    aws.Time(now),
+		Period:     aws.Int64(60), // Pontos de dados de um minuto
+		Statistics: []*string{aws.String(statistic)},
+	}
+
+	result, err := cwClient.GetMetricStatistics(input)
+	if err != nil {
+		return nil, err
+	}
+
+	datapoints := result.Datapoints
+	sort.Slice(datapoints, func(i, j int) bool { // Os pontos não vêm ordenados
+		return datapoints[i].Timestamp.Before(*datapoints[j].Timestamp)
+	})
+
+	values := []float64{}
+	for _, datapoint := range datapoints {
+		switch statistic {
+		case cloudwatch.StatisticMaximum:
+			values = append(values, aws.Float64Value(datapoint.Maximum))
+		case cloudwatch.StatisticMinimum:
+			values = append(values, aws.Float64Value(datapoint.Minimum))
+		case cloudwatch.StatisticSum:
+			values = append(values, aws.Float64Value(datapoint.Sum))
+		default:
+			values = append(values, aws.Float64Value(datapoint.Average))
+		}
+	}
+	return values, nil
+}
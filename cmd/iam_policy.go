@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// policyDocument representa um documento de política IAM (identidade ou confiança)
+type policyDocument struct {
+	Version   string        `json:"Version"`
+	Statement statementList `json:"Statement"`
+}
+
+// policyStatement representa um statement de política; os campos aceitam tanto string quanto lista
+type policyStatement struct {
+	Sid         string                           `json:"Sid,omitempty"`
+	Effect      string                           `json:"Effect"`
+	Principal   principalMap                     `json:"Principal,omitempty"`
+	Action      stringList                       `json:"Action,omitempty"`
+	NotAction   stringList                       `json:"NotAction,omitempty"`
+	Resource    stringList                       `json:"Resource,omitempty"`
+	NotResource stringList                       `json:"NotResource,omitempty"`
+	Condition   map[string]map[string]stringList `json:"Condition,omitempty"`
+}
+
+// stringList aceita um valor JSON que pode ser um valor único ou uma lista. Condições guardam
+// booleanos e números sem aspas (ex: {"aws:SecureTransport": false}), que são mantidos como o
+// texto JSON original.
+type stringList []string
+
+// UnmarshalJSON implementa json.Unmarshaler para stringList
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var list []json.RawMessage
+	if err := json.Unmarshal(data, &list); err != nil {
+		list = []json.RawMessage{data} // Valor único
+	}
+
+	values := stringList{}
+	for _, raw := range list {
+		var value string
+		if err := json.Unmarshal(raw, &value); err == nil {
+			values = append(values, value)
+			continue
+		}
+
+		var scalar interface{}
+		if err := json.Unmarshal(raw, &scalar); err != nil {
+			return err
+		}
+		if _, ok := scalar.(map[string]interface{}); ok {
+			return fmt.Errorf("unexpected object in policy value: %s", raw)
+		}
+		values = append(values, string(bytes.TrimSpace(raw)))
+	}
+	*l = values
+	return nil
+}
+
+// statementList aceita um único statement ou uma lista de statements
+type statementList []policyStatement
+
+// UnmarshalJSON implementa json.Unmarshaler para statementList
+func (l *statementList) UnmarshalJSON(data []byte) error {
+	var single policyStatement
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = statementList{single}
+		return nil
+	}
+
+	var list []policyStatement
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// principalMap agrupa os principals por tipo (AWS, Service, Federated). O principal
+// curinga "*" é representado como {"*": ["*"]}.
+type principalMap map[string]stringList
+
+// UnmarshalJSON implementa json.Unmarshaler para principalMap
+func (p *principalMap) UnmarshalJSON(data []byte) error {
+	var wildcard string
+	if err := json.Unmarshal(data, &wildcard); err == nil {
+		*p = principalMap{wildcard: {wildcard}}
+		return nil
+	}
+
+	principals := map[string]stringList{}
+	if err := json.Unmarshal(data, &principals); err != nil {
+		return err
+	}
+	*p = principals
+	return nil
+}
+
+// parsePolicyDocument decodifica um documento de política retornado pela API do IAM,
+// que vem codificado como URL
+func parsePolicyDocument(encoded string) (*policyDocument, error) {
+	decoded, err := url.QueryUnescape(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	document := &policyDocument{}
+	if err := json.Unmarshal([]byte(decoded), document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
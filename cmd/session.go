@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"lookr/deps" // Importação de pacotes locais ou dependências
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// newGlobalSession cria uma sessão para serviços globais (IAM, STS, Route 53, CloudFront),
+// para os quais basta a primeira região autorizada
+func newGlobalSession() (*session.Session, error) {
+	return session.NewSession(&aws.Config{
+		Region: aws.String(deps.AuthRegions()[0]),
+	})
+}
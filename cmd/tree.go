@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+)
+
+// treeNode representa um nó de uma árvore exibida no terminal
+type treeNode struct {
+	label    string
+	children []*treeNode
+}
+
+// add cria um filho com o rótulo informado e o retorna para permitir aninhamento
+func (n *treeNode) add(format string, a ...interface{}) *treeNode {
+	child := &treeNode{label: fmt.Sprintf(format, a...)}
+	n.children = append(n.children, child)
+	return child
+}
+
+// printTree imprime o nó e seus descendentes usando caracteres de desenho de caixa
+func printTree(root *treeNode) {
+	fmt.Println(root.label)
+	printChildren(root.children, "")
+}
+
+// printChildren imprime os filhos com o prefixo acumulado dos níveis anteriores
+func printChildren(children []*treeNode, prefix string) {
+	for i, child := range children {
+		connector, indent := "├── ", "│   "
+		if i == len(children)-1 { // O último filho fecha o ramo
+			connector, indent = "└── ", "    "
+		}
+		fmt.Println(prefix + connector + child.label)
+		printChildren(child.children, prefix+indent)
+	}
+}
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws" // Pacote AWS SDK para Go
+	"github.com/aws/aws-sdk-go/service/route53" // Pacote para AWS Route 53
+)
+
+// zoneNameTypes são os tipos cujo último campo do rdata é um nome de domínio, que pode ser relativo à origem
+var zoneNameTypes = map[string]bool{"CNAME": true, "NS": true, "PTR": true, "MX": true, "SRV": true}
+
+// zoneClasses são as classes aceitas em um arquivo de zona
+var zoneClasses = map[string]bool{"IN": true, "CH": true, "HS": true}
+
+// parseZoneFile lê um arquivo de zona no formato BIND e agrupa os registros por nome e tipo.
+// Suporta $ORIGIN, $TTL, comentários, parênteses em várias linhas, nomes relativos e `@`.
+func parseZoneFile(reader io.Reader, origin string) ([]*route53.ResourceRecordSet, error) {
+	origin = qualifyDNSName(origin, ".")
+	defaultTTL := int64(300)
+	previousName := ""
+
+	recordSets := []*route53.ResourceRecordSet{}
+	index := map[string]*route53.ResourceRecordSet{} // nome + tipo -> registro
+
+	entries, err := zoneEntries(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		fields := entry.fields
+
+		switch strings.ToUpper(fields[0]) {
+		case "$ORIGIN":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("line %d: $ORIGIN without a domain", entry.line)
+			}
+			origin = qualifyDNSName(fields[1], origin)
+			continue
+		case "$TTL":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("line %d: $TTL without a value", entry.line)
+			}
+			if defaultTTL, err = parseZoneTTL(fields[1]); err != nil {
+				return nil, fmt.Errorf("line %d: %v", entry.line, err)
+			}
+			continue
+		case "$INCLUDE", "$GENERATE":
+			return nil, fmt.Errorf("line %d: %s is not supported", entry.line, fields[0])
+		}
+
+		name := previousName
+		if !entry.continued { // Linhas indentadas herdam o nome anterior
+			name, fields = qualifyDNSName(fields[0], origin), fields[1:]
+		}
+		if name == "" {
+			return nil, fmt.Errorf("line %d: relative name without $ORIGIN", entry.line)
+		}
+		previousName = name
+
+		ttl := defaultTTL
+		for len(fields) > 0 { // TTL e classe são opcionais e podem vir em qualquer ordem
+			if zoneClasses[strings.ToUpper(fields[0])] {
+				fields = fields[1:]
+			} else if value, err := parseZoneTTL(fields[0]); err == nil {
+				ttl, fields = value, fields[1:]
+			} else {
+				break
+			}
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: expected a record type and data", entry.line)
+		}
+
+		recordType, rdata := strings.ToUpper(fields[0]), fields[1:]
+		if zoneNameTypes[recordType] {
+			if rdata[len(rdata)-1] = qualifyDNSName(rdata[len(rdata)-1], origin); rdata[len(rdata)-1] == "" {
+				return nil, fmt.Errorf("line %d: relative name without $ORIGIN", entry.line)
+			}
+		}
+
+		key := name + " " + recordType
+		recordSet, ok := index[key]
+		if !ok {
+			recordSet = &route53.ResourceRecordSet{Name: aws.String(name), Type: aws.String(recordType), TTL: aws.Int64(ttl)}
+			index[key] = recordSet
+			recordSets = append(recordSets, recordSet)
+		}
+		recordSet.ResourceRecords = append(recordSet.ResourceRecords, &route53.ResourceRecord{Value: aws.String(strings.Join(rdata, " "))})
+	}
+	return recordSets, nil
+}
+
+// zoneEntry é um registro lógico do arquivo de zona, já sem comentários e parênteses
+type zoneEntry struct {
+	line      int      // Linha onde o registro começa
+	continued bool     // Verdadeiro quando a linha começa com espaço e herda o nome anterior
+	fields    []string
+}
+
+// zoneEntries separa o arquivo em registros lógicos, juntando as linhas entre parênteses
+func zoneEntries(reader io.Reader) ([]zoneEntry, error) {
+	entries := []zoneEntry{}
+	scanner := bufio.NewScanner(reader)
+
+	var current *zoneEntry
+	depth, number := 0, 0
+	for scanner.Scan() {
+		number++
+		line := scanner.Text()
+
+		if depth == 0 {
+			current = &zoneEntry{line: number, continued: len(line) > 0 && (line[0] == ' ' || line[0] == '\t')}
+		}
+
+		fields, opened, err := zoneFields(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", number, err)
+		}
+		current.fields = append(current.fields, fields...)
+		depth += opened
+		if depth < 0 {
+			return nil, fmt.Errorf("line %d: unbalanced parentheses", number)
+		}
+
+		if depth == 0 && len(current.fields) > 0 {
+			entries = append(entries, *current)
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("line %d: unclosed parentheses", current.line)
+	}
+	return entries, scanner.Err()
+}
+
+// zoneFields divide uma linha em campos, mantendo as aspas de strings (necessárias em TXT)
+// e retornando o saldo de parênteses abertos
+func zoneFields(line string) ([]string, int, error) {
+	fields := []string{}
+	depth := 0
+
+	var field strings.Builder
+	quoted := false
+	flush := func() {
+		if field.Len() > 0 {
+			fields = append(fields, field.String())
+			field.Reset()
+		}
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			field.WriteByte(c)
+			field.WriteByte(line[i+1])
+			i++
+		case c == '"':
+			field.WriteByte(c)
+			quoted = !quoted
+		case quoted:
+			field.WriteByte(c)
+		case c == ';': // Comentário até o fim da linha
+			flush()
+			return fields, depth, nil
+		case c == '(' || c == ')':
+			flush()
+			if c == '(' {
+				depth++
+			} else {
+				depth--
+			}
+		case c == ' ' || c == '\t':
+			flush()
+		default:
+			field.WriteByte(c)
+		}
+	}
+	if quoted {
+		return nil, 0, fmt.Errorf("unterminated quoted string")
+	}
+	flush()
+	return fields, depth, nil
+}
+
+// parseZoneTTL converte um TTL em segundos, aceitando as unidades do BIND (ex: 1h30m, 2d)
+func parseZoneTTL(value string) (int64, error) {
+	units := map[byte]int64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+
+	total, digits := int64(0), ""
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= '0' && c <= '9' {
+			digits += string(c)
+			continue
+		}
+		unit, ok := units[c|0x20] // Unidades em maiúsculas ou minúsculas
+		if !ok || digits == "" {
+			return 0, fmt.Errorf("invalid TTL %q", value)
+		}
+		amount, _ := strconv.ParseInt(digits, 10, 64)
+		total, digits = total+amount*unit, ""
+	}
+	if value == "" {
+		return 0, fmt.Errorf("invalid TTL %q", value)
+	}
+	if digits != "" {
+		amount, err := strconv.ParseInt(digits, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid TTL %q", value)
+		}
+		total += amount
+	}
+	return total, nil
+}
+
+// qualifyDNSName completa um nome relativo com a origem; `@` representa a própria origem.
+// Sem origem, nomes relativos retornam vazio.
+func qualifyDNSName(name string, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return name
+	case name == "" || origin == "":
+		return ""
+	case origin == ".":
+		return name + "."
+	}
+	return name + "." + origin
+}
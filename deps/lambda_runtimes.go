@@ -0,0 +1,43 @@
+package deps
+
+import (
+	_ "embed"
+	"encoding/json"
+	"os"
+)
+
+// LambdaRuntime descreve as datas do calendário de descontinuação de um runtime Lambda.
+// As datas usam o formato AAAA-MM-DD e ficam vazias quando ainda não foram anunciadas.
+type LambdaRuntime struct {
+	Runtime     string `json:"runtime"`
+	Deprecation string `json:"deprecation"`
+	BlockCreate string `json:"blockCreate"`
+	BlockUpdate string `json:"blockUpdate"`
+}
+
+//go:embed lambda_runtimes.json
+var lambdaRuntimesJSON []byte
+
+// LambdaRuntimeCalendar retorna o calendário de descontinuação indexado pelo runtime.
+// Quando `path` é informado, o arquivo substitui o calendário embutido no binário.
+func LambdaRuntimeCalendar(path string) (map[string]LambdaRuntime, error) {
+	data := lambdaRuntimesJSON
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		data = content
+	}
+
+	runtimes := []LambdaRuntime{}
+	if err := json.Unmarshal(data, &runtimes); err != nil {
+		return nil, err
+	}
+
+	calendar := map[string]LambdaRuntime{}
+	for _, runtime := range runtimes {
+		calendar[runtime.Runtime] = runtime
+	}
+	return calendar, nil
+}
@@ -12,6 +12,7 @@ func main() {
 	var rootCmd = &cobra.Command{Use: "lookr"}
 	rootCmd.AddCommand(
 		cmd.AcmCmd,
+		cmd.AmisCmd,
 		cmd.AuroraCmd,
 		cmd.CloudFrontCmd,
 		cmd.DynamoDBCmd,
@@ -24,6 +25,7 @@ func main() {
 		cmd.LambdaCmd,
 		cmd.RdsCmd,
 		cmd.Route53Cmd,
+		cmd.SnapshotsCmd,
 		cmd.SqsCmd,
 	)
 	if err := rootCmd.Execute(); err != nil {